@@ -0,0 +1,38 @@
+// Package cache provides ready-to-use implementations of the Cache interface accepted by
+// the EnkaNetwork clients (see WithCache in the genshin, hsr, zzz and enka packages).
+//
+// # LRU
+//
+// LRU is an in-memory cache bounded by the number of entries. When it is full, the
+// least recently used entry is evicted to make room for a new one. Expired entries are
+// never returned and are removed in the background:
+//
+//	c := cache.NewLRU(1000)
+//	defer c.Close()
+//
+//	client := genshin.New(
+//	    genshin.WithUserAgent("my-app/1.0"),
+//	    genshin.WithCache(c),
+//	)
+//
+// Stats reports the number of hits, misses and evictions, which helps to choose the
+// size of the cache.
+//
+// # FileCache
+//
+// FileCache is persisted to a file, so that cached responses survive between runs of a
+// program, such as a CLI tool. Entries are loaded by NewFileCache and written back by
+// Flush, or by Close when the client is closed:
+//
+//	c, err := cache.NewFileCache("enka-cache.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client := genshin.New(genshin.WithCache(c))
+//	defer client.Close()
+//
+// Values are stored as JSON along with the name of their type. The clients register the
+// types they cache; other types must be registered with Register to be persisted.
+//
+// The package has no dependencies besides the standard library.
+package cache
@@ -0,0 +1,277 @@
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// types maps the names of the types registered with Register to the types.
+var (
+	typesMu sync.RWMutex
+	types   = make(map[string]reflect.Type)
+)
+
+// Register records the type of value, so that a FileCache can persist values of that type
+// and decode them when the file is loaded again. The clients register the types of the
+// values they cache (e.g., *genshin.Profile) when their package is initialized, so it is
+// only needed for values stored in a FileCache by other code.
+//
+// Values are persisted as JSON, so the type must round-trip through encoding/json.
+// Registering two different types with the same name panics.
+func Register(value any) {
+	t := reflect.TypeOf(value)
+	name := typeName(t)
+
+	typesMu.Lock()
+	defer typesMu.Unlock()
+
+	if prev, ok := types[name]; ok && prev != t {
+		panic("cache: registering duplicate types for " + name)
+	}
+	types[name] = t
+}
+
+// registeredType returns the type registered under name, if any.
+func registeredType(name string) (reflect.Type, bool) {
+	typesMu.RLock()
+	defer typesMu.RUnlock()
+
+	t, ok := types[name]
+	return t, ok
+}
+
+// typeName returns the name under which t is registered: its import path and name for
+// named types, e.g. "*github.com/kirinyoku/enkanetwork-go/client/genshin.Profile".
+func typeName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		return "*" + typeName(t.Elem())
+	}
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+	return t.String()
+}
+
+// ErrUnregisteredType is wrapped by the DecodeError of an entry whose type was not
+// registered with Register, e.g. because it was removed or renamed in a later version.
+var ErrUnregisteredType = errors.New("cache: unregistered type")
+
+// DecodeError is stored by a FileCache in place of a value of its file that cannot be
+// decoded, e.g. because the file was written by another version of the library. Get
+// returns it like any other value, so the clients ignore it and report it to the handler
+// set with WithCacheErrorHandler, which makes corrupt entries visible instead of only
+// causing additional requests. It is not written back to the file.
+type DecodeError struct {
+	Key  string // Key of the entry
+	Type string // Name of the type of the entry, as stored in the file
+	Err  error  // Error returned when decoding the value, or ErrUnregisteredType
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("cache: decoding %q as %s: %v", e.Key, e.Type, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// FileCache is a cache persisted to a file, so that cached responses survive between
+// runs of a program, such as a CLI tool invoked repeatedly. It implements the Cache
+// interface accepted by the EnkaNetwork clients and is safe for concurrent use.
+//
+// Entries are held in memory and written to the file by Flush, or by Close, which is
+// called when the client using the cache is closed. The file is read once by
+// NewFileCache. Values are stored as JSON along with the name of their type, which must
+// have been registered with Register; the clients register the types they cache. Values
+// of other types are kept in memory but not written to the file. Entries of the file
+// that cannot be decoded are returned as a *DecodeError.
+//
+// Unlike LRU, the number of entries is not limited. Expired entries are never returned
+// and are removed when accessed and when the cache is flushed.
+type FileCache struct {
+	mu      sync.Mutex
+	path    string
+	entries map[string]fileEntry
+}
+
+// fileEntry is a value stored in a FileCache.
+type fileEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// fileRecord is the representation of an entry in the file of a FileCache.
+type fileRecord struct {
+	Type      string          `json:"type"`      // Name of the type of the value (see Register)
+	Value     json.RawMessage `json:"value"`     // Value encoded as JSON
+	ExpiresAt time.Time       `json:"expiresAt"` // Expiration time of the entry
+}
+
+// NewFileCache creates a new FileCache persisted to the file at path and loads the
+// entries stored in it. A missing file is not an error: the cache starts empty and the
+// file is created by the first Flush. Expired entries are skipped. Entries of
+// unregistered types and entries that cannot be decoded are replaced by a *DecodeError.
+//
+// Call Close or Flush to write the entries to the file before the program exits.
+//
+// Example:
+//
+//	c, err := cache.NewFileCache(filepath.Join(os.TempDir(), "enka-cache.json"))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client := genshin.New(genshin.WithCache(c))
+//	defer client.Close() // Flushes the cache
+func NewFileCache(path string) (*FileCache, error) {
+	c := &FileCache{
+		path:    path,
+		entries: make(map[string]fileEntry),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var records map[string]fileRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("cache: invalid file %s: %w", path, err)
+	}
+
+	now := time.Now()
+	for key, record := range records {
+		if now.After(record.ExpiresAt) {
+			continue
+		}
+		c.entries[key] = fileEntry{value: decodeRecord(key, record), expiresAt: record.ExpiresAt}
+	}
+
+	return c, nil
+}
+
+// decodeRecord returns the value of record, the entry of key in the file, or a
+// *DecodeError if it cannot be decoded.
+func decodeRecord(key string, record fileRecord) any {
+	t, ok := registeredType(record.Type)
+	if !ok {
+		return &DecodeError{Key: key, Type: record.Type, Err: ErrUnregisteredType}
+	}
+	value := reflect.New(t)
+	if err := json.Unmarshal(record.Value, value.Interface()); err != nil {
+		return &DecodeError{Key: key, Type: record.Type, Err: err}
+	}
+	return value.Elem().Interface()
+}
+
+// Get retrieves a value from the cache by key. It returns the cached value and true if
+// found, or nil and false if the key is not present or its entry has expired.
+func (c *FileCache) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expiresAt) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// Set stores a value in the cache with the given key for the duration of expiration,
+// replacing any existing value for the key. A zero or negative expiration removes the
+// value for the key, as with LRU.Set. The value is written to the file by the next Flush.
+func (c *FileCache) Set(key string, value any, expiration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if expiration <= 0 {
+		delete(c.entries, key)
+		return
+	}
+	c.entries[key] = fileEntry{value: value, expiresAt: time.Now().Add(expiration)}
+}
+
+// Delete removes the value stored for key, if any.
+func (c *FileCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// Len returns the number of entries in the cache, including expired entries that have
+// not been removed yet.
+func (c *FileCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// Flush writes the unexpired entries of registered types to the file, replacing its
+// contents. The file is written to a temporary file first and then renamed, so an
+// interrupted Flush does not corrupt the previous contents.
+func (c *FileCache) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	records := make(map[string]fileRecord, len(c.entries))
+	for key, e := range c.entries {
+		if now.After(e.expiresAt) {
+			delete(c.entries, key)
+			continue
+		}
+		if e.value == nil {
+			continue
+		}
+		name := typeName(reflect.TypeOf(e.value))
+		if _, ok := registeredType(name); !ok {
+			continue
+		}
+		value, err := json.Marshal(e.value)
+		if err != nil {
+			return fmt.Errorf("cache: encoding %q: %w", key, err)
+		}
+		records[key] = fileRecord{Type: name, Value: value, ExpiresAt: e.expiresAt}
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(c.path), filepath.Base(c.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
+
+// Close flushes the cache (see Flush). It is called by the Close method of the clients,
+// so closing a client persists its cache. The cache remains usable after Close.
+func (c *FileCache) Close() error {
+	return c.Flush()
+}
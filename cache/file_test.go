@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+)
+
+var _ core.Cache = (*FileCache)(nil)
+
+// fileTestValue is a value persisted by the FileCache tests.
+type fileTestValue struct {
+	Name    string `json:"name"`
+	Enabled *bool  `json:"enabled,omitempty"`
+}
+
+// TestFileCachePersistence checks that registered, unexpired entries survive a flush and reload.
+func TestFileCachePersistence(t *testing.T) {
+	Register(&fileTestValue{})
+	path := filepath.Join(t.TempDir(), "cache.json")
+
+	c, err := NewFileCache(path)
+	if err != nil {
+		t.Fatalf("NewFileCache() error = %v", err)
+	}
+	enabled := false
+	value := &fileTestValue{Name: "Kirin", Enabled: &enabled}
+	c.Set("value", value, time.Hour)
+	c.Set("expired", value, time.Nanosecond)
+	c.Set("unregistered", struct{ Name string }{"Kirin"}, time.Hour)
+	time.Sleep(time.Millisecond)
+
+	if err := c.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if got, ok := c.Get("unregistered"); !ok || got == nil {
+		t.Error("expected unregistered values to remain in memory")
+	}
+
+	loaded, err := NewFileCache(path)
+	if err != nil {
+		t.Fatalf("NewFileCache() error = %v", err)
+	}
+	if n := loaded.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+	got, ok := loaded.Get("value")
+	if !ok || !reflect.DeepEqual(got, value) {
+		t.Errorf("Get(\"value\") = %v, %v, want %v, true", got, ok, value)
+	}
+}
+
+// TestFileCacheDecodeError checks that entries that cannot be decoded are returned as a *DecodeError.
+func TestFileCacheDecodeError(t *testing.T) {
+	Register(&fileTestValue{})
+	path := filepath.Join(t.TempDir(), "cache.json")
+	expiresAt := time.Now().Add(time.Hour).Format(time.RFC3339)
+	data := `{
+		"renamed": {"type": "*example.com/old.Profile", "value": {}, "expiresAt": "` + expiresAt + `"},
+		"changed": {"type": "` + typeName(reflect.TypeOf(&fileTestValue{})) + `", "value": {"name": 1}, "expiresAt": "` + expiresAt + `"}
+	}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	c, err := NewFileCache(path)
+	if err != nil {
+		t.Fatalf("NewFileCache() error = %v", err)
+	}
+
+	for _, key := range []string{"renamed", "changed"} {
+		got, _ := c.Get(key)
+		decodeErr, ok := got.(*DecodeError)
+		if !ok || decodeErr.Key != key {
+			t.Errorf("Get(%q) = %v, want a *DecodeError", key, got)
+			continue
+		}
+		if isUnregistered := errors.Is(decodeErr, ErrUnregisteredType); isUnregistered != (key == "renamed") {
+			t.Errorf("Get(%q) error = %v", key, decodeErr)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// cleanupInterval is how often the background goroutine of an LRU removes expired entries.
+const cleanupInterval = time.Minute
+
+// LRU is an in-memory cache holding up to a fixed number of entries. It implements the
+// Cache interface accepted by the EnkaNetwork clients and is safe for concurrent use.
+//
+// When a value is stored in a full cache, the least recently used entry is evicted.
+// Both Get and Set count as a use. Expired entries are never returned by Get; they are
+// removed when accessed and periodically by a background goroutine, which runs until
+// Close is called. With SetStaleRetention, expired entries are kept for a while longer
+// and can be read with GetStale.
+type LRU struct {
+	mu         sync.Mutex
+	maxEntries int
+	retention  time.Duration            // How long expired entries are kept for GetStale
+	ll         *list.List               // Entries, most recently used first
+	items      map[string]*list.Element // Maps keys to their element in ll
+	done       chan struct{}
+	closeOnce  sync.Once
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+// entry is a value stored in an LRU.
+type entry struct {
+	key       string
+	value     any
+	expiresAt time.Time
+}
+
+// NewLRU creates a new LRU cache holding up to maxEntries entries and starts the
+// background goroutine removing expired entries. If maxEntries is zero or less, the
+// number of entries is not limited and entries are only removed when they expire.
+//
+// Call Close when the cache is no longer needed to stop the background goroutine.
+func NewLRU(maxEntries int) *LRU {
+	c := &LRU{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		items:      make(map[string]*list.Element),
+		done:       make(chan struct{}),
+	}
+
+	go c.cleanup()
+
+	return c
+}
+
+// Get retrieves a value from the cache by key. It returns the cached value and true if
+// found, or nil and false if the key is not present or its entry has expired.
+func (c *LRU) Get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+	if now := time.Now(); now.After(e.expiresAt) {
+		if now.After(e.expiresAt.Add(c.retention)) {
+			c.removeElement(elem)
+		}
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	c.hits.Add(1)
+	return e.value, true
+}
+
+// GetStale retrieves a value from the cache by key, even if its entry has expired, as
+// long as it is still retained (see SetStaleRetention). It returns the cached value and
+// true if found, or nil and false otherwise. It does not count as a hit or a miss.
+//
+// It implements the StaleCache interface used by the WithServeStaleOnError option of the
+// clients.
+func (c *LRU) GetStale(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*entry)
+	if time.Now().After(e.expiresAt.Add(c.retention)) {
+		c.removeElement(elem)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return e.value, true
+}
+
+// SetStaleRetention sets how long entries are kept after they expire, so they can still
+// be read with GetStale, e.g. to serve stale data when the API is down. Retained entries
+// still count toward the maximum number of entries. A zero or negative duration, the
+// default, removes entries as soon as they expire.
+func (c *LRU) SetStaleRetention(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.retention = max(d, 0)
+}
+
+// Set stores a value in the cache with the given key for the duration of expiration,
+// replacing any existing value for the key. If the cache is full, the least recently
+// used entry is evicted.
+//
+// A zero or negative expiration means the value is already expired: it is not stored,
+// and any existing value for the key is removed. This matches the clients, which do not
+// cache responses without a positive TTL.
+func (c *LRU) Set(key string, value any, expiration time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if expiration <= 0 {
+		if elem, ok := c.items[key]; ok {
+			c.removeElement(elem)
+		}
+		return
+	}
+
+	expiresAt := time.Now().Add(expiration)
+
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry)
+		e.value = value
+		e.expiresAt = expiresAt
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&entry{key: key, value: value, expiresAt: expiresAt})
+
+	if c.maxEntries > 0 && c.ll.Len() > c.maxEntries {
+		c.removeElement(c.ll.Back())
+		c.evictions.Add(1)
+	}
+}
+
+// Delete removes the value stored for key, if any.
+func (c *LRU) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+// Len returns the number of entries in the cache, including expired entries that have
+// not been removed yet.
+func (c *LRU) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ll.Len()
+}
+
+// Stats returns the number of cache hits and misses of Get and the number of entries
+// evicted to make room for new ones since the cache was created. Expired entries count
+// as misses when accessed, but their removal is not counted as an eviction, so a high
+// number of evictions indicates that the cache is too small.
+//
+// The counters are updated atomically and can be read while the cache is in use.
+//
+// Example:
+//
+//	hits, misses, _ := c.Stats()
+//	ratio := float64(hits) / float64(hits+misses)
+func (c *LRU) Stats() (hits, misses, evictions uint64) {
+	return c.hits.Load(), c.misses.Load(), c.evictions.Load()
+}
+
+// Close stops the background goroutine removing expired entries. The cache remains
+// usable after Close; expired entries are then only removed when accessed. Calling
+// Close more than once has no effect.
+func (c *LRU) Close() {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+}
+
+// cleanup periodically removes expired entries until Close is called.
+func (c *LRU) cleanup() {
+	ticker := time.NewTicker(cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.removeExpired()
+		case <-c.done:
+			return
+		}
+	}
+}
+
+// removeExpired removes all expired entries that are no longer retained.
+func (c *LRU) removeExpired() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for elem := c.ll.Back(); elem != nil; {
+		prev := elem.Prev()
+		if now.After(elem.Value.(*entry).expiresAt.Add(c.retention)) {
+			c.removeElement(elem)
+		}
+		elem = prev
+	}
+}
+
+// removeElement removes elem from the cache. The caller must hold c.mu.
+func (c *LRU) removeElement(elem *list.Element) {
+	c.ll.Remove(elem)
+	delete(c.items, elem.Value.(*entry).key)
+}
@@ -0,0 +1,120 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+)
+
+var _ core.Cache = (*LRU)(nil)
+
+// TestLRUEviction checks that the least recently used entry is evicted when the cache is full.
+func TestLRUEviction(t *testing.T) {
+	c := NewLRU(2)
+	defer c.Close()
+
+	c.Set("a", 1, time.Minute)
+	c.Set("b", 2, time.Minute)
+	c.Get("a")
+	c.Set("c", 3, time.Minute)
+
+	if _, ok := c.Get("b"); ok {
+		t.Error("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+	if n := c.Len(); n != 2 {
+		t.Errorf("expected 2 entries, got %d", n)
+	}
+}
+
+// TestLRUExpiration checks that expired entries are not returned and non-positive
+// expirations are not stored.
+func TestLRUExpiration(t *testing.T) {
+	c := NewLRU(0)
+	defer c.Close()
+
+	c.Set("short", 1, time.Millisecond)
+	c.Set("zero", 2, 0)
+	c.Set("negative", 3, -time.Second)
+	time.Sleep(5 * time.Millisecond)
+
+	for _, key := range []string{"short", "zero", "negative"} {
+		if _, ok := c.Get(key); ok {
+			t.Errorf("expected %s not to be cached", key)
+		}
+	}
+
+	c.Set("long", 4, time.Minute)
+	c.Set("long", 5, 0)
+	if _, ok := c.Get("long"); ok {
+		t.Error("expected a zero expiration to remove the existing value")
+	}
+}
+
+// TestLRURemoveExpired checks that the cleanup removes only expired entries.
+func TestLRURemoveExpired(t *testing.T) {
+	c := NewLRU(0)
+	defer c.Close()
+
+	c.Set("short", 1, time.Millisecond)
+	c.Set("long", 2, time.Minute)
+	time.Sleep(5 * time.Millisecond)
+	c.removeExpired()
+
+	if n := c.Len(); n != 1 {
+		t.Errorf("expected 1 entry, got %d", n)
+	}
+	if v, ok := c.Get("long"); !ok || v != 2 {
+		t.Errorf("expected long to be cached, got %v, %v", v, ok)
+	}
+}
+
+// TestLRUStats checks that hits, misses and evictions are counted.
+func TestLRUStats(t *testing.T) {
+	c := NewLRU(1)
+	defer c.Close()
+
+	c.Set("a", 1, time.Minute)
+	c.Get("a")
+	c.Get("b")
+	c.Set("b", 2, time.Minute)
+	c.Get("a")
+	c.Set("c", 3, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	c.Get("c")
+
+	hits, misses, evictions := c.Stats()
+	if hits != 1 || misses != 3 || evictions != 2 {
+		t.Errorf("Stats() = %d, %d, %d, want 1, 3, 2", hits, misses, evictions)
+	}
+}
+
+// TestLRUStaleRetention checks that expired entries are only returned by GetStale while retained.
+func TestLRUStaleRetention(t *testing.T) {
+	c := NewLRU(0)
+	defer c.Close()
+	c.SetStaleRetention(time.Hour)
+
+	c.Set("key", 1, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	if _, ok := c.Get("key"); ok {
+		t.Error("expected Get not to return an expired entry")
+	}
+	if value, ok := c.GetStale("key"); !ok || value != 1 {
+		t.Errorf("GetStale() = %v, %v, want 1, true", value, ok)
+	}
+
+	c.SetStaleRetention(0)
+	if _, ok := c.GetStale("key"); ok {
+		t.Error("expected GetStale not to return an entry past its retention")
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", c.Len())
+	}
+}
@@ -0,0 +1,256 @@
+package enka
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxAccountConcurrency is the maximum number of hoyo accounts fetched concurrently by
+// GetFullAccount and GetUserProfileHoyosBuilds.
+const maxAccountConcurrency = 4
+
+// FullAccount bundles an Enka user profile with all of its hoyo accounts and their
+// builds, as returned by GetFullAccount.
+type FullAccount struct {
+	Owner *Owner        // The user profile
+	Hoyos []HoyoAccount // The user's hoyo accounts, in the order set on Enka
+}
+
+// HoyoAccount contains the data of a single hoyo account fetched by GetFullAccount.
+//
+// A hoyo account whose details or builds could not be fetched is still included, with
+// the error recorded in Err. Hoyo then holds the summary returned by
+// GetUserProfileHoyos, and Builds is nil if the builds could not be fetched.
+type HoyoAccount struct {
+	Hash   string          // Hash of the hoyo account
+	Hoyo   *Hoyo           // Details of the hoyo account
+	Builds AvatarBuildsMap // Builds of the hoyo account
+	Err    error           // Error encountered while fetching the account, if any
+}
+
+// GetFullAccount fetches the complete picture of an Enka user: the user profile, every
+// hoyo account linked to it and the builds of each account.
+//
+// It combines GetUserProfile, GetUserProfileHoyos, GetUserProfileHoyo and
+// GetUserProfileHoyoBuilds, fetching up to 4 hoyo accounts concurrently. Each request
+// goes through the cache and rate limiter of the client like a direct call would.
+//
+// If the user profile or the list of hoyo accounts cannot be fetched, the whole call
+// fails. Errors fetching a single hoyo account, such as ErrHoyoAccountBuildsNotFound,
+// are recorded in the Err field of that account instead, so the other accounts are
+// still returned.
+//
+// Parameters:
+//   - ctx: A context.Context to control the requests' timeout or cancellation.
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
+//
+// Returns:
+//   - *FullAccount: The user profile and hoyo accounts if successful.
+//   - error: An error if the user profile or the list of hoyo accounts cannot be fetched,
+//     or the context is done before all accounts are fetched.
+//
+// Possible errors include:
+//   - ErrInvalidUsername: If the username is empty or invalid.
+//   - ErrUserNotFound: If the user does not exist.
+//
+// Example:
+//
+//	account, err := client.GetFullAccount(ctx, "Algoinde")
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+//	for _, hoyo := range account.Hoyos {
+//	    if hoyo.Err != nil {
+//	        fmt.Println(hoyo.Hash, "failed:", hoyo.Err)
+//	        continue
+//	    }
+//	    fmt.Println(hoyo.Hash, hoyo.Builds.Count(), "builds")
+//	}
+func (c *Client) GetFullAccount(ctx context.Context, username string) (*FullAccount, error) {
+	owner, err := c.GetUserProfile(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	hoyos, err := c.GetUserProfileHoyos(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	account := &FullAccount{
+		Owner: owner,
+		Hoyos: make([]HoyoAccount, 0, len(hoyos)),
+	}
+	for hash, hoyo := range hoyos {
+		account.Hoyos = append(account.Hoyos, HoyoAccount{Hash: hash, Hoyo: &hoyo})
+	}
+	slices.SortFunc(account.Hoyos, func(a, b HoyoAccount) int {
+		return cmp.Or(core.CompareNumeric(a.Hoyo.Order, b.Hoyo.Order), cmp.Compare(a.Hash, b.Hash))
+	})
+
+	forEachLimited(len(account.Hoyos), func(i int) {
+		// Each call writes only to its own element, so no locking is needed
+		h := &account.Hoyos[i]
+
+		hoyo, hoyoErr := c.GetUserProfileHoyo(ctx, username, h.Hash)
+		if hoyoErr == nil {
+			h.Hoyo = hoyo
+		}
+
+		builds, buildsErr := c.GetUserProfileHoyoBuilds(ctx, username, h.Hash)
+		if buildsErr == nil {
+			h.Builds = builds
+		}
+
+		h.Err = errors.Join(hoyoErr, buildsErr)
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetUserProfileHoyosBuilds fetches the builds of every hoyo account linked to an Enka
+// user, keyed by hoyo hash.
+//
+// It gets the list of hoyo accounts with GetUserProfileHoyos, then fetches the builds of
+// up to 4 accounts concurrently with GetUserProfileHoyoBuilds. Each request goes through
+// the cache and rate limiter of the client like a direct call would.
+//
+// A failure for a single account does not abort the others: the builds of the accounts
+// that could be fetched are returned together with an error joining the failures, each
+// prefixed with the hash of its account. Use errors.Is to check for specific errors,
+// such as ErrHoyoAccountBuildsNotFound.
+//
+// Parameters:
+//   - ctx: A context.Context to control the requests' timeout or cancellation.
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
+//
+// Returns:
+//   - map[string]AvatarBuildsMap: The builds of each hoyo account that could be fetched.
+//   - error: An error if the list of hoyo accounts cannot be fetched or the context is
+//     done, in which case the map is nil, or the joined errors of the accounts whose
+//     builds could not be fetched.
+//
+// Possible errors include:
+//   - ErrInvalidUsername: If the username is empty or invalid.
+//   - ErrUserNotFound: If the user does not exist.
+//
+// Example:
+//
+//	builds, err := client.GetUserProfileHoyosBuilds(ctx, "Algoinde")
+//	if err != nil {
+//	    fmt.Println("Some builds are missing:", err)
+//	}
+//	for hash, avatarBuilds := range builds {
+//	    fmt.Println(hash, avatarBuilds.Count(), "builds")
+//	}
+func (c *Client) GetUserProfileHoyosBuilds(ctx context.Context, username string) (map[string]AvatarBuildsMap, error) {
+	hoyos, err := c.GetUserProfileHoyos(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, 0, len(hoyos))
+	for hash := range hoyos {
+		hashes = append(hashes, hash)
+	}
+	slices.Sort(hashes)
+
+	results := make([]AvatarBuildsMap, len(hashes))
+	errs := make([]error, len(hashes))
+	forEachLimited(len(hashes), func(i int) {
+		results[i], errs[i] = c.GetUserProfileHoyoBuilds(ctx, username, hashes[i])
+	})
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	builds := make(map[string]AvatarBuildsMap, len(hashes))
+	for i, hash := range hashes {
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("hoyo %s: %w", hash, errs[i])
+			continue
+		}
+		builds[hash] = results[i]
+	}
+
+	return builds, errors.Join(errs...)
+}
+
+// GetUserGameProfile returns the primary account of an Enka user for a single game: the
+// verified and public hoyo account of the given type with the lowest Order, i.e. the
+// first one listed on the user's Enka profile. It is a shortcut for fetching the hoyo
+// accounts with GetUserProfileHoyos and picking one.
+//
+// Parameters:
+//   - ctx: A context.Context to control the request's timeout or cancellation.
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
+//   - hoyoType: The game of the account (0 for Genshin, 1 for HSR, 2 for ZZZ).
+//
+// Returns:
+//   - *Hoyo: The summary of the account, as returned by GetUserProfileHoyos.
+//   - error: An error if the request fails or the user has no such account.
+//
+// Possible errors include:
+//   - ErrInvalidUsername: If the username is empty or invalid.
+//   - ErrUserNotFound: If the user does not exist.
+//   - ErrHoyoAccountNotFound: If the user has no verified and public account of the game.
+//
+// Example:
+//
+//	hoyo, err := client.GetUserGameProfile(ctx, "Algoinde", 0)
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+//	fmt.Println("Genshin UID:", hoyo.UID)
+func (c *Client) GetUserGameProfile(ctx context.Context, username string, hoyoType int) (*Hoyo, error) {
+	hoyos, err := c.GetUserProfileHoyos(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	var primary *Hoyo
+	for hash, hoyo := range hoyos {
+		if hoyo.HoyoType != hoyoType || !hoyo.Verified || !hoyo.Public {
+			continue
+		}
+		if hoyo.Hash == "" {
+			hoyo.Hash = hash
+		}
+		if primary == nil || cmp.Or(core.CompareNumeric(hoyo.Order, primary.Order), cmp.Compare(hoyo.Hash, primary.Hash)) < 0 {
+			primary = &hoyo
+		}
+	}
+
+	if primary == nil {
+		return nil, ErrHoyoAccountNotFound
+	}
+
+	return primary, nil
+}
+
+// forEachLimited calls fn for every index from 0 to n-1, running up to
+// maxAccountConcurrency calls at a time, and returns once all of them are done.
+func forEachLimited(n int, fn func(i int)) {
+	var g errgroup.Group
+	g.SetLimit(maxAccountConcurrency)
+	for i := range n {
+		g.Go(func() error {
+			fn(i)
+			return nil
+		})
+	}
+	g.Wait()
+}
@@ -0,0 +1,217 @@
+package enka
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kirinyoku/enkanetwork-go/cache"
+	"github.com/kirinyoku/enkanetwork-go/internal/testutil"
+)
+
+// TestGetFullAccount checks that a hoyo account failing to load is recorded without failing the others,
+// both by GetFullAccount and GetUserProfileHoyosBuilds.
+func TestGetFullAccount(t *testing.T) {
+	responses := map[string]string{
+		"/api/profile/Algoinde/":                `{"id":1,"username":"Algoinde"}`,
+		"/api/profile/Algoinde/hoyos/":          `{"b":{"uid":2,"order":"2"},"a":{"uid":1,"order":"10"}}`,
+		"/api/profile/Algoinde/hoyos/a/":        `{"uid":1,"order":"10","region":"EU"}`,
+		"/api/profile/Algoinde/hoyos/a/builds/": `{"10000002":[{"id":7,"avatar_data":{}}]}`,
+		"/api/profile/Algoinde/hoyos/b/":        `{"uid":2,"order":"2","region":"NA"}`,
+	}
+	doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		body, ok := responses[strings.TrimSuffix(req.URL.Path, "/")+"/"]
+		status := http.StatusOK
+		if !ok {
+			status = http.StatusNotFound
+		}
+		return testutil.Response(req, status, body), nil
+	})
+
+	client := New(WithHTTPDoer(doer), WithNoRetry())
+
+	account, err := client.GetFullAccount(context.Background(), "Algoinde")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if account.Owner.Username != "Algoinde" || len(account.Hoyos) != 2 {
+		t.Fatalf("unexpected account: %+v", account)
+	}
+
+	b, a := account.Hoyos[0], account.Hoyos[1]
+	if b.Hash != "b" || a.Hash != "a" {
+		t.Errorf("hoyos = [%s %s], want [b a]", b.Hash, a.Hash)
+	}
+	if a.Err != nil || a.Hoyo.Region != "EU" || a.Builds.Count() != 1 {
+		t.Errorf("hoyo a = %+v, want region EU and 1 build", a)
+	}
+	if !errors.Is(b.Err, ErrHoyoAccountBuildsNotFound) || b.Hoyo.Region != "NA" || b.Builds != nil {
+		t.Errorf("hoyo b = %+v, want region NA and ErrHoyoAccountBuildsNotFound", b)
+	}
+
+	if _, err := client.GetFullAccount(context.Background(), "nobody"); err != ErrUserNotFound {
+		t.Errorf("expected ErrUserNotFound, got %v", err)
+	}
+
+	builds, err := client.GetUserProfileHoyosBuilds(context.Background(), "Algoinde")
+	if !errors.Is(err, ErrHoyoAccountBuildsNotFound) {
+		t.Errorf("expected ErrHoyoAccountBuildsNotFound, got %v", err)
+	}
+	if len(builds) != 1 || builds["a"].Count() != 1 {
+		t.Errorf("builds = %v, want 1 build for hoyo a only", builds)
+	}
+}
+
+// TestGetUserGameProfile checks that the verified and public account of the game with the lowest order is returned.
+func TestGetUserGameProfile(t *testing.T) {
+	hoyos := `{
+		"a": {"uid": 1, "order": "10", "hoyo_type": 0, "verified": true, "public": true},
+		"b": {"uid": 2, "order": "2", "hoyo_type": 0, "verified": true, "public": true},
+		"c": {"uid": 3, "order": "1", "hoyo_type": 0, "verified": false, "public": true},
+		"d": {"uid": 4, "order": "0", "hoyo_type": 1, "verified": true, "public": true}
+	}`
+	doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		return testutil.Response(req, http.StatusOK, hoyos), nil
+	})
+
+	client := New(WithHTTPDoer(doer), WithNoRetry())
+
+	tests := []struct {
+		hoyoType int
+		wantUID  int
+		wantErr  error
+	}{
+		{0, 2, nil},
+		{1, 4, nil},
+		{2, 0, ErrHoyoAccountNotFound},
+	}
+
+	for _, tt := range tests {
+		hoyo, err := client.GetUserGameProfile(context.Background(), "Algoinde", tt.hoyoType)
+		if err != tt.wantErr {
+			t.Errorf("GetUserGameProfile(%d) error = %v, want %v", tt.hoyoType, err, tt.wantErr)
+			continue
+		}
+		if err == nil && hoyo.UID != tt.wantUID {
+			t.Errorf("GetUserGameProfile(%d) UID = %d, want %d", tt.hoyoType, hoyo.UID, tt.wantUID)
+		}
+	}
+}
+
+// TestGetUserProfileCaseInsensitive checks that WithCaseInsensitiveUsernames shares cache
+// entries between spellings of a username, while requests keep the given spelling.
+func TestGetUserProfileCaseInsensitive(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		requests []string
+	}{
+		{"default", nil, []string{"/api/profile/Algoinde", "/api/profile/algoinde"}},
+		{"case insensitive", []Option{WithCaseInsensitiveUsernames()}, []string{"/api/profile/Algoinde"}},
+	}
+
+	for _, tt := range tests {
+		var requests []string
+		doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			requests = append(requests, req.URL.Path)
+			return testutil.Response(req, http.StatusOK, `{"username": "Algoinde"}`), nil
+		})
+
+		c := cache.NewLRU(10)
+		client := New(append(tt.opts, WithCache(c), WithHTTPDoer(doer))...)
+
+		for _, username := range []string{"Algoinde", "algoinde"} {
+			if _, err := client.GetUserProfile(context.Background(), username); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+		}
+		if !reflect.DeepEqual(requests, tt.requests) {
+			t.Errorf("%s: requests = %v, want %v", tt.name, requests, tt.requests)
+		}
+		c.Close()
+	}
+}
+
+// TestGetUserProfileHoyoBuildsSortedCached checks that sorting returns a copy, leaving the
+// cached builds in the order of the API response.
+func TestGetUserProfileHoyoBuildsSortedCached(t *testing.T) {
+	doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		return testutil.Response(req, http.StatusOK, `{"10000002":[{"id":2,"order":"2"},{"id":1,"order":"1"}]}`), nil
+	})
+
+	c := cache.NewLRU(10)
+	defer c.Close()
+	client := New(WithCache(c), WithHTTPDoer(doer))
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sorted, err := client.GetUserProfileHoyoBuildsSorted(context.Background(), "Algoinde", "4Wjv2e")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if got := []int{sorted["10000002"][0].ID, sorted["10000002"][1].ID}; !reflect.DeepEqual(got, []int{1, 2}) {
+				t.Errorf("sorted IDs = %v, want [1 2]", got)
+			}
+		}()
+	}
+	wg.Wait()
+
+	builds, err := client.GetUserProfileHoyoBuilds(context.Background(), "Algoinde", "4Wjv2e")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := []int{builds["10000002"][0].ID, builds["10000002"][1].ID}; !reflect.DeepEqual(got, []int{2, 1}) {
+		t.Errorf("cached IDs = %v, want [2 1]", got)
+	}
+}
+
+// closeRecorder records whether a response body was closed.
+type closeRecorder struct {
+	io.Reader
+	closed bool
+}
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+// TestIterUserProfileHoyoBuildsTruncated checks that a response cut off while the builds
+// are decoded is reported by Err and closed, after yielding the builds received.
+func TestIterUserProfileHoyoBuildsTruncated(t *testing.T) {
+	body := &closeRecorder{Reader: strings.NewReader(`{"10000002":[{"id":1},{"id":2}],"1309":[{"id":3,"na`)}
+	doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		resp := testutil.Response(req, http.StatusOK, "")
+		resp.Body = body
+		return resp, nil
+	})
+
+	client := New(WithHTTPDoer(doer))
+	builds, err := client.IterUserProfileHoyoBuilds(context.Background(), "Algoinde", "4Wjv2e")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []int
+	for _, build := range builds.All() {
+		ids = append(ids, build.ID)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("IDs = %v, want [1 2]", ids)
+	}
+	if err := builds.Err(); !errors.Is(err, ErrTruncatedResponse) {
+		t.Errorf("Err() = %v, want ErrTruncatedResponse", err)
+	}
+	if !body.closed {
+		t.Errorf("expected the response body to be closed")
+	}
+}
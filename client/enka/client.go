@@ -2,10 +2,9 @@ package enka
 
 import (
 	"context"
-	"fmt"
 	"net/http"
-	"time"
 
+	"github.com/kirinyoku/enkanetwork-go/cache"
 	"github.com/kirinyoku/enkanetwork-go/internal/core"
 	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
 	"github.com/kirinyoku/enkanetwork-go/internal/core/fetcher"
@@ -23,6 +22,9 @@ import (
 // Create a Client using the NewClient function, which allows customization of these
 // settings. Once created, use the Client to call methods like GetUserProfile to fetch
 // user data.
+//
+// A Client is safe for concurrent use by multiple goroutines, and should be reused
+// rather than created for each request.
 type Client struct {
 	*core.Client   // Embeds core.Client for shared HTTP and caching functionality
 	profileFetcher *fetcher.Fetcher[Owner]
@@ -31,21 +33,65 @@ type Client struct {
 	buildsFetcher  *fetcher.Fetcher[AvatarBuildsMap]
 }
 
-// NewClient creates a new Enka API client for making requests.
-//
-// This function allows customization of the client with a custom HTTP client, cache
-// implementation, and User-Agent string. If not provided, default values are used:
-// a standard HTTP client with a 10-second timeout, no cache, and a default User-Agent
-// of "enkanetwork-go-client/1.0".
-//
-// Parameters:
-//   - httpClient: An optional *http.Client for making HTTP requests. If nil, a default
-//     client with a 10-second timeout is used.
-//   - cache: An optional Cache implementation for storing responses. If nil, caching
-//     is disabled.
-//   - userAgent: A string to set as the User-Agent header in requests. If empty, the
-//     default "enkanetwork-go-client/1.0" is used. A unique User-Agent, such as
-//     "my-app/1.0", is recommended to identify the application.
+// init registers the types of the values cached by the client, so that a
+// cache.FileCache can persist them.
+func init() {
+	cache.Register(&Owner{})
+	cache.Register(Hoyos{})
+	cache.Register(&Hoyo{})
+	cache.Register(AvatarBuildsMap{})
+}
+
+// New creates a new Enka API client configured with the given options.
+//
+// Options allow you to customize the client by providing your own HTTP client, cache
+// implementation, User-Agent string or retry configuration. Options that are not
+// provided fall back to default values: a standard HTTP client with a 10-second
+// timeout, no cache, DefaultUserAgent as the User-Agent, and up to 3 attempts for
+// requests failing with a transient error.
+//
+// Available options:
+//   - WithHTTPClient: A custom *http.Client for making HTTP requests.
+//   - WithHTTPDoer: A custom HTTPDoer sending the requests, e.g. a mock in tests.
+//   - WithTransport: A custom http.RoundTripper, such as one using a proxy or client
+//     certificates, installed on the default HTTP client.
+//   - WithCache: A Cache implementation for storing responses.
+//   - WithUserAgent: A string to set as the User-Agent header in requests. It's
+//     recommended to set a unique User-Agent to identify your application, such as
+//     "my-app/1.0".
+//   - WithUserAgentSuffix: A string appended to the User-Agent, e.g. to identify your
+//     application while keeping the library's User-Agent.
+//   - WithRetryConfig: The number of attempts and the default delay between them.
+//   - WithNoRetry: Disables retries, so errors are returned after a single attempt.
+//   - WithRetryOnMaintenance: Retries requests failing with 424 during maintenance.
+//   - WithRetryBudget: A budget of retries shared across requests and clients.
+//   - WithClock: A fake clock for tests, controlling the delays between retries.
+//   - WithRequireUserAgent: Makes a custom User-Agent mandatory.
+//   - WithRateLimiter: A rate limiter waited on before every request, such as
+//     rate.NewLimiter from golang.org/x/time/rate.
+//   - WithMaxConcurrency: The maximum number of requests in flight at once.
+//   - WithRequestTimeout: A timeout for each request attempt, without the need for a
+//     custom HTTP client.
+//   - WithFallbackTimeout: The timeout of request attempts that nothing else bounds,
+//     30 seconds by default.
+//   - WithMaxResponseSize: The maximum size of a response body, 16 MB by default.
+//   - WithAPIVersion: A version prefix for the endpoint paths, e.g. "v2".
+//   - WithLanguage: The language of localized responses, e.g. "ja".
+//   - WithConditionalRequests: Sends If-None-Match with the ETag of the last response
+//     and reuses its body when the API answers 304 Not Modified.
+//   - WithHeaders: Additional headers sent with every request, e.g. for an auth proxy.
+//   - WithMinCacheTTL, WithMaxCacheTTL: Bounds of how long responses are cached.
+//   - WithCacheErrorHandler: A function called when a cached value cannot be used.
+//   - WithServeStaleOnError: Returns expired cached values when a request fails with a
+//     transient error, along with ErrStaleData.
+//   - WithCaseInsensitiveUsernames: Shares cache entries between usernames differing
+//     only in case.
+//
+// The User-Agent is validated when the client is created. If it is invalid, or missing
+// while WithRequireUserAgent is used, Err returns ErrInvalidUserAgent or
+// ErrUserAgentRequired, and every request fails with the same error.
+// Likewise, a language set with WithLanguage that is not supported by EnkaNetwork makes
+// Err and every request return ErrInvalidLanguage.
 //
 // Returns:
 //   - A pointer to a new Enka-specific Client instance ready to make API requests.
@@ -53,22 +99,32 @@ type Client struct {
 // Example:
 //
 //	// Create a client with default settings
-//	client := enka.NewClient(nil, nil, "my-app/1.0")
+//	client := enka.New(enka.WithUserAgent("my-app/1.0"))
 //	// Create a client with a custom HTTP client
 //	customClient := &http.Client{Timeout: 20 * time.Second}
-//	client := enka.NewClient(customClient, nil, "my-app/1.0")
-func NewClient(httpClient *http.Client, cache core.Cache, userAgent string) *Client {
-	c := core.NewClient(httpClient, cache, userAgent)
+//	client := enka.New(enka.WithHTTPClient(customClient), enka.WithUserAgent("my-app/1.0"))
+func New(opts ...Option) *Client {
+	c := core.New(opts...)
 
 	return &Client{
 		Client:         c,
-		profileFetcher: fetcher.NewFetcher[Owner](c.HTTPClient, c.UserAgent),
-		hoyosFetcher:   fetcher.NewFetcher[Hoyos](c.HTTPClient, c.UserAgent),
-		hoyoFetcher:    fetcher.NewFetcher[Hoyo](c.HTTPClient, c.UserAgent),
-		buildsFetcher:  fetcher.NewFetcher[AvatarBuildsMap](c.HTTPClient, c.UserAgent),
+		profileFetcher: fetcher.NewFetcher[Owner](c),
+		hoyosFetcher:   fetcher.NewFetcher[Hoyos](c),
+		hoyoFetcher:    fetcher.NewFetcher[Hoyo](c),
+		buildsFetcher:  fetcher.NewFetcher[AvatarBuildsMap](c),
 	}
 }
 
+// NewClient creates a new Enka API client with the given HTTP client, cache and
+// User-Agent. Zero values fall back to the same defaults as New.
+//
+// Deprecated: Use New with the WithHTTPClient, WithCache and WithUserAgent options
+// instead. NewClient is kept for backward compatibility and will not receive new
+// configuration parameters.
+func NewClient(httpClient *http.Client, cache core.Cache, userAgent string) *Client {
+	return New(WithHTTPClient(httpClient), WithCache(cache), WithUserAgent(userAgent))
+}
+
 // GetUserProfile fetches the Enka user profile for the given username.
 //
 // Enka allows users to create a profile and link multiple game accounts to it.
@@ -80,18 +136,23 @@ func NewClient(httpClient *http.Client, cache core.Cache, userAgent string) *Cli
 //
 // Unlike GetProfile, this method does not use a TTL for caching because user profiles
 // do not include a TTL value. Instead, successful responses are cached for a fixed
-// duration of 5 minutes to reduce API requests.
+// duration of 5 minutes to reduce API requests, adjusted by WithMinCacheTTL and
+// WithMaxCacheTTL if provided.
+//
+// The username is sent as given; whether it matches a user with a different case is
+// decided by the API. Responses are cached under the username as given, unless the
+// client was created with WithCaseInsensitiveUsernames.
 //
 // Parameters:
 //   - ctx: A context.Context to control the request's timeout or cancellation.
-//   - username: The username of the EnkaNetwork user (must not be empty).
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
 //
 // Returns:
 //   - *Owner: A pointer to the user's profile if successful.
 //   - error: An error if the request fails.
 //
 // Possible errors include:
-//   - ErrInvalidUsername: If the username is empty.
+//   - ErrInvalidUsername: If the username is empty or invalid.
 //   - ErrUserNotFound: If the user does not exist.
 //   - Other errors for network issues or unexpected HTTP status codes.
 //
@@ -106,35 +167,37 @@ func NewClient(httpClient *http.Client, cache core.Cache, userAgent string) *Cli
 //	fmt.Println("Username:", owner.Username)
 //	fmt.Println("Bio:", owner.Profile.Bio)
 func (c *Client) GetUserProfile(ctx context.Context, username string) (*Owner, error) {
-	if username == "" {
+	if !core.IsValidUsername(username) {
 		return nil, ErrInvalidUsername
 	}
 
-	key := fmt.Sprintf("user_%s", username)
+	key := core.CacheKey("enka", "user", c.UsernameCacheKey(username))
 
-	if c.Cache != nil {
+	if c.Cache != nil && !core.BypassCacheFromContext(ctx) {
 		if cached, ok := c.Cache.Get(key); ok {
-			if owner, ok := cached.(*Owner); ok {
+			if owner, ok := core.CachedAs[*Owner](c.Client, key, cached); ok {
 				return owner, nil
 			}
 		}
 	}
 
-	url := fmt.Sprintf("%s/profile/%s", core.BaseURL, username)
+	url := c.URL(core.EnkaProfilePath(username))
 
-	owner, err := c.profileFetcher.FetchWithRetry(ctx, url)
-	if err != nil {
-		if err == errors.ErrPlayerNotFound {
-			return nil, ErrUserNotFound
+	return core.Do(ctx, c.Client, key, func(ctx context.Context) (*Owner, error) {
+		owner, err := c.profileFetcher.FetchWithRetry(ctx, url)
+		if err != nil {
+			if errors.Is(err, errors.ErrPlayerNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return core.StaleOnError[*Owner](c.Client, key, err)
 		}
-		return nil, err
-	}
 
-	if c.Cache != nil {
-		c.Cache.Set(key, owner, 5*time.Minute)
-	}
+		if c.Cache != nil {
+			c.Cache.Set(key, owner, c.ClampCacheTTL(core.DefaultCacheTTL))
+		}
 
-	return owner, nil
+		return owner, nil
+	})
 }
 
 // GetUserProfileHoyos fetches a list of “hoyos” — verified and public game accounts
@@ -151,14 +214,14 @@ func (c *Client) GetUserProfile(ctx context.Context, username string) (*Owner, e
 //
 // Parameters:
 //   - ctx: A context.Context to control the request's timeout or cancellation.
-//   - username: The username of the EnkaNetwork user (must not be empty).
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
 //
 // Returns:
 //   - Hoyos: Map where the key is the hoyo hash and the value is the Hoyo struct.
 //   - error: An error if the request fails.
 //
 // Possible errors include:
-//   - ErrInvalidUsername: If the username is empty.
+//   - ErrInvalidUsername: If the username is empty or invalid.
 //   - ErrUserNotFound: If the user does not exist.
 //
 // Example:
@@ -171,35 +234,37 @@ func (c *Client) GetUserProfile(ctx context.Context, username string) (*Owner, e
 //	}
 //	fmt.Println("Hoyos:", hoyos)
 func (c *Client) GetUserProfileHoyos(ctx context.Context, username string) (Hoyos, error) {
-	if username == "" {
+	if !core.IsValidUsername(username) {
 		return nil, ErrInvalidUsername
 	}
 
-	key := fmt.Sprintf("user_%s_hoyos", username)
+	key := core.CacheKey("enka", "user", c.UsernameCacheKey(username), "hoyos")
 
-	if c.Cache != nil {
+	if c.Cache != nil && !core.BypassCacheFromContext(ctx) {
 		if cached, ok := c.Cache.Get(key); ok {
-			if hoyos, ok := cached.(Hoyos); ok {
+			if hoyos, ok := core.CachedAs[Hoyos](c.Client, key, cached); ok {
 				return hoyos, nil
 			}
 		}
 	}
 
-	url := fmt.Sprintf("%s/profile/%s/hoyos", core.BaseURL, username)
+	url := c.URL(core.EnkaHoyosPath(username))
 
-	hoyos, err := c.hoyosFetcher.FetchWithRetry(ctx, url)
-	if err != nil {
-		if err == errors.ErrPlayerNotFound {
-			return nil, ErrUserNotFound
+	return core.Do(ctx, c.Client, key, func(ctx context.Context) (Hoyos, error) {
+		hoyos, err := c.hoyosFetcher.FetchWithRetry(ctx, url)
+		if err != nil {
+			if errors.Is(err, errors.ErrPlayerNotFound) {
+				return nil, ErrUserNotFound
+			}
+			return core.StaleOnError[Hoyos](c.Client, key, err)
 		}
-		return nil, err
-	}
 
-	if c.Cache != nil {
-		c.Cache.Set(key, hoyos, 5*time.Minute)
-	}
+		if c.Cache != nil {
+			c.Cache.Set(key, *hoyos, c.ClampCacheTTL(core.DefaultCacheTTL))
+		}
 
-	return *hoyos, nil
+		return *hoyos, nil
+	})
 }
 
 // GetUserProfileHoyo fetches information about a specific Hoyo account.
@@ -210,16 +275,16 @@ func (c *Client) GetUserProfileHoyos(ctx context.Context, username string) (Hoyo
 //
 // Parameters:
 //   - ctx: A context.Context to control the request's timeout or cancellation.
-//   - username: The username of the EnkaNetwork user (must not be empty).
-//   - hoyo_hash: The hash of the hoyo (must not be empty).
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
+//   - hoyo_hash: The hash of the hoyo (see IsValidHoyoHash).
 //
 // Returns:
 //   - *Hoyo: A pointer to the hoyo data if successful.
 //   - error: An error if the request fails.
 //
 // Possible errors include:
-//   - ErrInvalidUsername: If the username is empty.
-//   - ErrInvalidHoyoHash: If the hoyo hash is empty.
+//   - ErrInvalidUsername: If the username is empty or invalid.
+//   - ErrInvalidHoyoHash: If the hoyo hash is empty or invalid.
 //   - ErrHoyoAccountNotFound: If the hoyo account does not exist.
 //
 // Example:
@@ -232,39 +297,41 @@ func (c *Client) GetUserProfileHoyos(ctx context.Context, username string) (Hoyo
 //	}
 //	fmt.Println("Hoyo:", hoyo)
 func (c *Client) GetUserProfileHoyo(ctx context.Context, username string, hoyo_hash string) (*Hoyo, error) {
-	if username == "" {
+	if !core.IsValidUsername(username) {
 		return nil, ErrInvalidUsername
 	}
 
-	if hoyo_hash == "" {
+	if !core.IsValidHoyoHash(hoyo_hash) {
 		return nil, ErrInvalidHoyoHash
 	}
 
-	key := fmt.Sprintf("user_%s_hoyos_%s", username, hoyo_hash)
+	key := core.CacheKey("enka", "user", c.UsernameCacheKey(username), "hoyos", hoyo_hash)
 
-	if c.Cache != nil {
+	if c.Cache != nil && !core.BypassCacheFromContext(ctx) {
 		if cached, ok := c.Cache.Get(key); ok {
-			if hoyo, ok := cached.(*Hoyo); ok {
+			if hoyo, ok := core.CachedAs[*Hoyo](c.Client, key, cached); ok {
 				return hoyo, nil
 			}
 		}
 	}
 
-	url := fmt.Sprintf("%s/profile/%s/hoyos/%s", core.BaseURL, username, hoyo_hash)
+	url := c.URL(core.EnkaHoyoPath(username, hoyo_hash))
 
-	hoyo, err := c.hoyoFetcher.FetchWithRetry(ctx, url)
-	if err != nil {
-		if err == errors.ErrPlayerNotFound {
-			return nil, ErrHoyoAccountNotFound
+	return core.Do(ctx, c.Client, key, func(ctx context.Context) (*Hoyo, error) {
+		hoyo, err := c.hoyoFetcher.FetchWithRetry(ctx, url)
+		if err != nil {
+			if errors.Is(err, errors.ErrPlayerNotFound) {
+				return nil, ErrHoyoAccountNotFound
+			}
+			return core.StaleOnError[*Hoyo](c.Client, key, err)
 		}
-		return nil, err
-	}
 
-	if c.Cache != nil {
-		c.Cache.Set(key, hoyo, 5*time.Minute)
-	}
+		if c.Cache != nil {
+			c.Cache.Set(key, hoyo, c.ClampCacheTTL(core.DefaultCacheTTL))
+		}
 
-	return hoyo, nil
+		return hoyo, nil
+	})
 }
 
 // GetUserProfileHoyoBuilds fetches character builds for a specific Hoyo account.
@@ -284,8 +351,8 @@ func (c *Client) GetUserProfileHoyo(ctx context.Context, username string, hoyo_h
 //
 // Parameters:
 //   - ctx: A context.Context to control the request's timeout or cancellation.
-//   - username: The username of the EnkaNetwork user (must not be empty).
-//   - hoyo_hash: The hash of the hoyo (must not be empty).
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
+//   - hoyo_hash: The hash of the hoyo (see IsValidHoyoHash).
 //
 // Returns:
 //   - AvatarBuildsMap: A map where the key is the avatarID and the value is a slice of builds for that character.
@@ -301,37 +368,150 @@ func (c *Client) GetUserProfileHoyo(ctx context.Context, username string, hoyo_h
 //	}
 //	fmt.Println("avatarBuilds:", avatarBuilds)
 func (c *Client) GetUserProfileHoyoBuilds(ctx context.Context, username string, hoyo_hash string) (AvatarBuildsMap, error) {
-	if username == "" {
+	if !core.IsValidUsername(username) {
 		return nil, ErrInvalidUsername
 	}
 
-	if hoyo_hash == "" {
+	if !core.IsValidHoyoHash(hoyo_hash) {
 		return nil, ErrInvalidHoyoHash
 	}
 
-	key := fmt.Sprintf("user_%s_hoyos_%s_builds", username, hoyo_hash)
+	key := c.LocalizedCacheKey("enka", "user", c.UsernameCacheKey(username), "hoyos", hoyo_hash, "builds")
 
-	if c.Cache != nil {
+	if c.Cache != nil && !core.BypassCacheFromContext(ctx) {
 		if cached, ok := c.Cache.Get(key); ok {
-			if builds, ok := cached.(AvatarBuildsMap); ok {
+			if builds, ok := core.CachedAs[AvatarBuildsMap](c.Client, key, cached); ok {
 				return builds, nil
 			}
 		}
 	}
 
-	url := fmt.Sprintf("%s/profile/%s/hoyos/%s/builds", core.BaseURL, username, hoyo_hash)
+	url := c.LocalizedURL(core.EnkaBuildsPath(username, hoyo_hash))
 
-	builds, err := c.buildsFetcher.FetchWithRetry(ctx, url)
-	if err != nil {
-		if err == errors.ErrPlayerNotFound {
-			return nil, ErrHoyoAccountBuildsNotFound
+	return core.Do(ctx, c.Client, key, func(ctx context.Context) (AvatarBuildsMap, error) {
+		builds, err := c.buildsFetcher.FetchWithRetry(ctx, url)
+		if err != nil {
+			if errors.Is(err, errors.ErrPlayerNotFound) {
+				return nil, ErrHoyoAccountBuildsNotFound
+			}
+			return core.StaleOnError[AvatarBuildsMap](c.Client, key, err)
 		}
+
+		if c.Cache != nil {
+			c.Cache.Set(key, *builds, c.ClampCacheTTL(core.DefaultCacheTTL))
+		}
+
+		return *builds, nil
+	})
+}
+
+// GetUserProfileHoyoBuildsSorted fetches character builds for a specific Hoyo account
+// and sorts the builds of each character by their Order field.
+//
+// It behaves exactly like GetUserProfileHoyoBuilds, except that the builds are sorted
+// with AvatarBuildsMap.Sort before being returned: saved builds come first in ascending
+// order, followed by live builds. The result is a sorted copy, so the cached builds
+// keep the order of the API response.
+//
+// Parameters:
+//   - ctx: A context.Context to control the request's timeout or cancellation.
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
+//   - hoyo_hash: The hash of the hoyo (see IsValidHoyoHash).
+//
+// Returns:
+//   - AvatarBuildsMap: A map where the key is the avatarID and the value is a sorted slice of builds for that character.
+//   - error: An error if the request fails, such as ErrInvalidUsername or ErrHoyoAccountBuildsNotFound.
+//
+// Example:
+//
+//	ctx := context.Background()
+//	avatarBuilds, err := client.GetUserProfileHoyoBuildsSorted(ctx, "Algoinde", "4Wjv2e")
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+//	for avatarID, builds := range avatarBuilds {
+//	    fmt.Println(avatarID, builds[0].Name)
+//	}
+func (c *Client) GetUserProfileHoyoBuildsSorted(ctx context.Context, username string, hoyo_hash string) (AvatarBuildsMap, error) {
+	builds, err := c.GetUserProfileHoyoBuilds(ctx, username, hoyo_hash)
+	if err != nil {
 		return nil, err
 	}
 
+	return builds.Sort(), nil
+}
+
+// IterUserProfileHoyoBuilds fetches character builds for a specific Hoyo account and
+// returns an iterator over them, yielding the avatarID and each build as it is decoded.
+//
+// Unlike GetUserProfileHoyoBuilds, the builds are not collected into an AvatarBuildsMap:
+// the response is decoded as it is received, so processing a large number of builds one
+// at a time, or stopping early, does not require reading all of them into memory. The
+// request is made and checked for errors before IterUserProfileHoyoBuilds returns;
+// errors occurring while the builds are decoded, such as a response cut off by a reset
+// connection, are reported by the Err method of the iterator.
+//
+// Streaming only applies to clients without a cache. If the client has a cache, the
+// builds are fetched with GetUserProfileHoyoBuilds instead, which decodes the whole
+// response into an AvatarBuildsMap held in memory (and in the cache) before the
+// iterator yields them ordered by avatarID; in exchange, they are cached and concurrent
+// calls are coalesced like with the other methods. Create a client without WithCache to
+// keep memory use bounded for large accounts. Without a cache, each call sends its own
+// request and decodes its own response.
+//
+// Parameters:
+//   - ctx: A context.Context to control the request's timeout or cancellation.
+//   - username: The username of the EnkaNetwork user (see IsValidUsername).
+//   - hoyo_hash: The hash of the hoyo (see IsValidHoyoHash).
+//
+// Returns:
+//   - *BuildsIterator: An iterator over the avatarID and the builds of each character.
+//     Range over its All method, then check its Err method.
+//   - error: An error if the request fails, such as ErrInvalidUsername or ErrHoyoAccountBuildsNotFound.
+//
+// Example:
+//
+//	ctx := context.Background()
+//	builds, err := client.IterUserProfileHoyoBuilds(ctx, "Algoinde", "4Wjv2e")
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+//	for avatarID, build := range builds.All() {
+//	    if build.Public {
+//	        fmt.Println(avatarID, build.Name)
+//	    }
+//	}
+//	if err := builds.Err(); err != nil {
+//	    fmt.Println("Error:", err)
+//	}
+func (c *Client) IterUserProfileHoyoBuilds(ctx context.Context, username string, hoyo_hash string) (*BuildsIterator, error) {
 	if c.Cache != nil {
-		c.Cache.Set(key, builds, 5*time.Minute)
+		builds, err := c.GetUserProfileHoyoBuilds(ctx, username, hoyo_hash)
+		if err != nil {
+			return nil, err
+		}
+		return &BuildsIterator{cached: builds}, nil
+	}
+
+	if !core.IsValidUsername(username) {
+		return nil, ErrInvalidUsername
+	}
+
+	if !core.IsValidHoyoHash(hoyo_hash) {
+		return nil, ErrInvalidHoyoHash
+	}
+
+	url := c.LocalizedURL(core.EnkaBuildsPath(username, hoyo_hash))
+
+	body, err := c.buildsFetcher.FetchStream(ctx, url)
+	if err != nil {
+		if errors.Is(err, errors.ErrPlayerNotFound) {
+			return nil, ErrHoyoAccountBuildsNotFound
+		}
+		return nil, err
 	}
 
-	return *builds, nil
+	return &BuildsIterator{body: body}, nil
 }
@@ -13,7 +13,7 @@
 // To start using the package, create a new client instance and make API calls:
 //
 //	// Create a new client with default settings
-//	client := enka.NewClient(nil, nil, "my-app/1.0")
+//	client := enka.New(enka.WithUserAgent("my-app/1.0"))
 //
 //	// Fetch a user profile
 //	profile, err := client.GetUserProfile(context.Background(), "Algoinde")
@@ -27,6 +27,27 @@
 // made to the EnkaNetwork API. You can provide any implementation of the core.Cache interface
 // when creating a new client.
 //
+// The cache package provides a ready-to-use in-memory implementation bounded by the
+// number of entries:
+//
+//	c := cache.NewLRU(1000)
+//	defer c.Close()
+//	client := enka.New(enka.WithCache(c))
+//
+// Concurrent requests for the same resource are coalesced: while a request is in flight,
+// other callers asking for the same data wait for it and share its result instead of
+// sending their own request.
+//
+// To get fresh data for a single request, for example after a user updated their
+// showcase, use WithBypassCache. The cached response is skipped, but the new one is
+// still written to the cache:
+//
+//	owner, err := client.GetUserProfile(enka.WithBypassCache(ctx), username)
+//
+// To keep serving data while the API is unavailable, use WithServeStaleOnError with a
+// cache that retains expired entries, such as cache.LRU after SetStaleRetention. Failed
+// requests then return the last cached value along with ErrStaleData.
+//
 // # Rate Limiting
 //
 // The package includes built-in retry logic for handling rate limits (HTTP 429 responses).
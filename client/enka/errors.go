@@ -1,11 +1,32 @@
 package enka
 
-import "errors"
+import "github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+
+// APIError carries details of a failed request beyond the error it wraps, such as the
+// delay requested by the API's Retry-After header when ErrRateLimited is returned. Use
+// errors.As to read it.
+type APIError = errors.APIError
 
 var (
-	ErrInvalidUsername           = errors.New("username cannot be empty")
-	ErrUserNotFound              = errors.New("user not found")
-	ErrHoyoAccountNotFound       = errors.New("hoyo account not found")
-	ErrHoyoAccountBuildsNotFound = errors.New("no builds found for hoyo account")
-	ErrInvalidHoyoHash           = errors.New("hoyo_hash cannot be empty")
+	ErrInvalidUsername           = errors.ErrInvalidUsername
+	ErrUserNotFound              = errors.ErrUserNotFound
+	ErrHoyoAccountNotFound       = errors.ErrHoyoAccountNotFound
+	ErrHoyoAccountBuildsNotFound = errors.ErrHoyoAccountBuildsNotFound
+	ErrInvalidHoyoHash           = errors.ErrInvalidHoyoHash
+	ErrTruncatedResponse         = errors.ErrTruncatedResponse
+	ErrResponseTooLarge          = errors.ErrResponseTooLarge
+	ErrNoContent                 = errors.ErrNoContent
+	ErrUnexpectedStatus          = errors.ErrUnexpectedStatus
+
+	ErrUserAgentRequired = errors.ErrUserAgentRequired
+	ErrInvalidUserAgent  = errors.ErrInvalidUserAgent
+	ErrInvalidLanguage   = errors.ErrInvalidLanguage
+
+	// ErrStaleData is returned along with a stale cached value when a request fails with
+	// a transient error and the client was created with WithServeStaleOnError.
+	ErrStaleData = errors.ErrStaleData
+
+	// ErrCacheTypeMismatch is reported to the handler set with WithCacheErrorHandler
+	// when a cached value does not have the expected type and is ignored.
+	ErrCacheTypeMismatch = errors.ErrCacheTypeMismatch
 )
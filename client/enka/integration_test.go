@@ -7,13 +7,13 @@
 package enka
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"reflect"
 	"testing"
 
 	"github.com/kirinyoku/enkanetwork-go/internal/core"
@@ -92,9 +92,8 @@ func TestGetUserProfile(t *testing.T) {
 	apiJSON = core.RemoveTTLField(apiJSON)
 	clientJSON = core.RemoveTTLField(clientJSON)
 
-	// Compare JSON responses
-	if !reflect.DeepEqual(apiJSON, clientJSON) {
-		t.Errorf("JSON responses do not match. API JSON: %s\nClient JSON: %s", apiJSON, clientJSON)
+	if missing := core.DiffJSONKeys(apiJSON, clientJSON); len(missing) > 0 {
+		t.Errorf("API response contains fields missing from client structs: %v", missing)
 	}
 }
 
@@ -140,9 +139,8 @@ func TestGetUserProfileHoyos(t *testing.T) {
 	apiJSON = core.RemoveTTLField(apiJSON)
 	clientJSON = core.RemoveTTLField(clientJSON)
 
-	// Compare JSON responses
-	if !reflect.DeepEqual(apiJSON, clientJSON) {
-		t.Errorf("JSON responses do not match. API JSON: %s\nClient JSON: %s", apiJSON, clientJSON)
+	if missing := core.DiffJSONKeys(apiJSON, clientJSON); len(missing) > 0 {
+		t.Errorf("API response contains fields missing from client structs: %v", missing)
 	}
 }
 
@@ -188,8 +186,8 @@ func TestGetUserProfileHoyo(t *testing.T) {
 	apiJSON = core.RemoveTTLField(apiJSON)
 	clientJSON = core.RemoveTTLField(clientJSON)
 
-	if !reflect.DeepEqual(apiJSON, clientJSON) {
-		t.Errorf("JSON responses do not match. API JSON: %s\nClient JSON: %s", apiJSON, clientJSON)
+	if missing := core.DiffJSONKeys(apiJSON, clientJSON); len(missing) > 0 {
+		t.Errorf("API response contains fields missing from client structs: %v", missing)
 	}
 }
 
@@ -235,20 +233,21 @@ func TestGetUserProfileHoyoBuilds(t *testing.T) {
 		t.Fatalf("failed to unmarshal API JSON into struct: %v", err)
 	}
 
-	clientJSONBytes, err := json.Marshal(builds)
+	apiJSONBytes, err = json.Marshal(apiData)
 	if err != nil {
-		t.Fatalf("failed to marshal client response to JSON: %v", err)
+		t.Fatalf("failed to marshal API response to JSON: %v", err)
 	}
 
-	clientJSONBytes = core.RemoveTTLField(clientJSONBytes)
-
-	var clientData AvatarBuildsMap
-	err = json.Unmarshal(clientJSONBytes, &clientData)
+	clientJSONBytes, err := json.Marshal(builds)
 	if err != nil {
-		t.Fatalf("failed to unmarshal client marshaled JSON into struct: %v", err)
+		t.Fatalf("failed to marshal client response to JSON: %v", err)
 	}
 
-	if !reflect.DeepEqual(apiData, clientData) {
+	// Compare normalized forms, so differences in key order or number formatting are ignored
+	apiJSONBytes = core.NormalizeJSON(apiJSONBytes)
+	clientJSONBytes = core.NormalizeJSON(core.RemoveTTLField(clientJSONBytes))
+
+	if !bytes.Equal(apiJSONBytes, clientJSONBytes) {
 		t.Errorf("JSON responses do not match. API JSON: %s\nClient JSON: %s", apiJSONBytes, clientJSONBytes)
 	}
 }
@@ -1,11 +1,20 @@
 package enka
 
 import (
+	"bytes"
+	"cmp"
 	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sort"
 
 	"github.com/kirinyoku/enkanetwork-go/client/genshin"
 	"github.com/kirinyoku/enkanetwork-go/client/hsr"
 	"github.com/kirinyoku/enkanetwork-go/client/zzz"
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
 	"github.com/kirinyoku/enkanetwork-go/models"
 )
 
@@ -14,6 +23,234 @@ import (
 // "order" field that can be used to sort them for display.
 type AvatarBuildsMap map[string][]Build
 
+// Sort returns a copy of the map in which the builds of each character are sorted
+// ascending by their Order field. Saved builds come first, followed by live builds;
+// builds with equal order are sorted by ID, so the result does not depend on the order
+// returned by the API.
+//
+// Sort does not modify its receiver, so it is safe to call on a map returned by the
+// client, which may be shared with the cache and with concurrent callers.
+func (m AvatarBuildsMap) Sort() AvatarBuildsMap {
+	sorted := make(AvatarBuildsMap, len(m))
+	for avatarID, builds := range m {
+		sorted[avatarID] = slices.SortedFunc(slices.Values(builds), compareBuilds)
+	}
+	return sorted
+}
+
+// compareBuilds orders builds as described by Sort.
+func compareBuilds(a, b Build) int {
+	if a.Live != b.Live {
+		if a.Live {
+			return 1
+		}
+		return -1
+	}
+	if c := core.CompareNumeric(a.Order, b.Order); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.ID, b.ID)
+}
+
+// All returns an iterator over the avatarID and each build of the map, ordered by
+// avatarID. The builds of each character are yielded in their order in the slice.
+func (m AvatarBuildsMap) All() iter.Seq2[string, Build] {
+	return func(yield func(string, Build) bool) {
+		avatarIDs := make([]string, 0, len(m))
+		for avatarID := range m {
+			avatarIDs = append(avatarIDs, avatarID)
+		}
+		sort.Slice(avatarIDs, func(i, j int) bool {
+			return core.CompareNumeric(avatarIDs[i], avatarIDs[j]) < 0
+		})
+
+		for _, avatarID := range avatarIDs {
+			for _, build := range m[avatarID] {
+				if !yield(avatarID, build) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// InAvatarOrder returns the avatarIDs of the map sorted by order, which maps avatarIDs
+// to their position, such as the AvatarOrder field of a Hoyo. Characters missing from
+// order come last, sorted by avatarID. Use it to iterate over the builds in the order
+// the user set on Enka:
+//
+//	for _, avatarID := range builds.InAvatarOrder(hoyo.AvatarOrder) {
+//	    fmt.Println(avatarID, len(builds[avatarID]))
+//	}
+func (m AvatarBuildsMap) InAvatarOrder(order map[string]int) []string {
+	avatarIDs := make([]string, 0, len(m))
+	for avatarID := range m {
+		avatarIDs = append(avatarIDs, avatarID)
+	}
+	sortAvatarIDs(avatarIDs, order)
+	return avatarIDs
+}
+
+// sortAvatarIDs sorts avatarIDs in place by their position in order. AvatarIDs missing
+// from order come last; avatarIDs with equal positions are sorted by avatarID.
+func sortAvatarIDs(avatarIDs []string, order map[string]int) {
+	sort.Slice(avatarIDs, func(i, j int) bool {
+		a, aOK := order[avatarIDs[i]]
+		b, bOK := order[avatarIDs[j]]
+		if aOK != bOK {
+			return aOK
+		}
+		if a != b {
+			return a < b
+		}
+		return core.CompareNumeric(avatarIDs[i], avatarIDs[j]) < 0
+	})
+}
+
+// BuildsIterator iterates over the builds returned by IterUserProfileHoyoBuilds. Range
+// over All to read the builds, then check Err, like with a bufio.Scanner:
+//
+//	for avatarID, build := range builds.All() {
+//	    fmt.Println(avatarID, build.Name)
+//	}
+//	if err := builds.Err(); err != nil {
+//	    fmt.Println("Error:", err)
+//	}
+//
+// A BuildsIterator decoding a response can only be iterated once; call Close if the
+// builds are not iterated at all.
+type BuildsIterator struct {
+	cached AvatarBuildsMap // Builds read from the cache, if any
+	body   io.ReadCloser   // Body of the response, decoded by All
+	err    error
+}
+
+// All returns an iterator over the avatarID and each build. Builds decoded from the
+// response are yielded as they are received, in the order of the response, and the
+// response is closed when the iteration ends. Cached builds are yielded ordered by
+// avatarID (see AvatarBuildsMap.All).
+//
+// The iteration stops at the first error, such as a response that ends early or does
+// not match the Build struct; Err reports it.
+func (it *BuildsIterator) All() iter.Seq2[string, Build] {
+	if it.cached != nil {
+		return it.cached.All()
+	}
+	return func(yield func(string, Build) bool) {
+		if it.body == nil {
+			return
+		}
+		defer it.Close()
+		if err := decodeBuilds(it.body, yield); err != nil {
+			it.err = err
+		}
+	}
+}
+
+// Err returns the error that stopped the iteration over All, or nil if every build was
+// yielded or the iteration was stopped by the caller.
+func (it *BuildsIterator) Err() error {
+	return it.err
+}
+
+// Close closes the response if it has not been iterated until the end. It is safe to
+// call several times.
+func (it *BuildsIterator) Close() error {
+	if it.body == nil {
+		return nil
+	}
+	err := it.body.Close()
+	it.body = nil
+	return err
+}
+
+// decodeBuilds decodes the builds of a builds response read from r, which maps
+// avatarIDs to arrays of builds, and yields them one at a time. It returns nil if all the
+// builds were yielded or yield returned false, and otherwise the error that stopped the
+// decoding. A response that ends early results in an error wrapping
+// ErrTruncatedResponse.
+func decodeBuilds(r io.Reader, yield func(string, Build) bool) error {
+	d := &buildsDecoder{r: r}
+	d.dec = json.NewDecoder(d)
+	if err := d.expectDelim('{'); err != nil {
+		return err
+	}
+
+	for d.dec.More() {
+		tok, err := d.dec.Token()
+		if err != nil {
+			return d.error(err)
+		}
+		avatarID, _ := tok.(string)
+
+		// A character without builds may be null instead of an empty array
+		tok, err = d.dec.Token()
+		if err != nil {
+			return d.error(err)
+		}
+		if tok == nil {
+			continue
+		}
+		if tok != json.Delim('[') {
+			return fmt.Errorf("failed to decode builds: unexpected %v for avatar %s", tok, avatarID)
+		}
+
+		for d.dec.More() {
+			var build Build
+			if err := d.dec.Decode(&build); err != nil {
+				return d.error(err)
+			}
+			if !yield(avatarID, build) {
+				return nil
+			}
+		}
+
+		if err := d.expectDelim(']'); err != nil {
+			return err
+		}
+	}
+
+	return d.expectDelim('}')
+}
+
+// buildsDecoder decodes a builds response read from r, keeping track of whether the
+// end of r was reached, to tell a response that was cut off from a malformed one.
+type buildsDecoder struct {
+	r   io.Reader
+	dec *json.Decoder
+	eof bool // Whether r returned io.EOF
+}
+
+func (d *buildsDecoder) Read(p []byte) (int, error) {
+	n, err := d.r.Read(p)
+	if err == io.EOF {
+		d.eof = true
+	}
+	return n, err
+}
+
+// expectDelim reads the next token and returns an error if it is not delim.
+func (d *buildsDecoder) expectDelim(delim json.Delim) error {
+	tok, err := d.dec.Token()
+	if err != nil {
+		return d.error(err)
+	}
+	if tok != delim {
+		return fmt.Errorf("failed to decode builds: unexpected %v, want %v", tok, delim)
+	}
+	return nil
+}
+
+// error wraps an error returned by the decoder. A syntax error at the end of the input
+// means that the response was cut off, so it is reported as ErrTruncatedResponse.
+func (d *buildsDecoder) error(err error) error {
+	var syntaxErr *json.SyntaxError
+	if err == io.EOF || err == io.ErrUnexpectedEOF || (d.eof && errors.As(err, &syntaxErr)) {
+		return fmt.Errorf("failed to decode builds: %w: %w", errors.ErrTruncatedResponse, err)
+	}
+	return fmt.Errorf("failed to decode builds: %w", err)
+}
+
 // Build contains information about a specific character build.
 type Build struct {
 	ID       int    `json:"id,omitempty"`        // ID of the build
@@ -35,6 +272,79 @@ type Build struct {
 	HoyoType int      `json:"hoyo_type"`        // ID of the Hoyo game (0 for Genshin, 1 for HSR, 2 for ZZZ)
 }
 
+// UnmarshalJSON implements the json.Unmarshaler interface for Build. It decodes the
+// avatar data into the AvatarData field matching HoyoType only, so a build is never
+// mistaken for one of another game. The avatar data of a game the library does not
+// know yet is kept in AvatarData.Raw.
+func (b *Build) UnmarshalJSON(data []byte) error {
+	// plain has the fields of Build without its methods, to avoid infinite recursion
+	type plain Build
+	if err := json.Unmarshal(data, (*plain)(b)); err != nil {
+		return err
+	}
+
+	return b.AvatarData.decode(b.HoyoType)
+}
+
+// Filter returns a new AvatarBuildsMap containing only the builds for which keep
+// returns true. Characters left without builds are omitted from the result.
+// The original map is not modified.
+func (m AvatarBuildsMap) Filter(keep func(Build) bool) AvatarBuildsMap {
+	filtered := make(AvatarBuildsMap)
+	for avatarID, builds := range m {
+		var kept []Build
+		for _, build := range builds {
+			if keep(build) {
+				kept = append(kept, build)
+			}
+		}
+		if len(kept) > 0 {
+			filtered[avatarID] = kept
+		}
+	}
+	return filtered
+}
+
+// FilterByHoyoType returns the builds belonging to accounts of the given game
+// (0 for Genshin, 1 for HSR, 2 for ZZZ).
+func (m AvatarBuildsMap) FilterByHoyoType(t int) AvatarBuildsMap {
+	return m.Filter(func(b Build) bool { return b.HoyoType == t })
+}
+
+// OnlyLive returns the live builds, i.e. builds retrieved from the game's showcase.
+func (m AvatarBuildsMap) OnlyLive() AvatarBuildsMap {
+	return m.Filter(func(b Build) bool { return b.Live })
+}
+
+// OnlySaved returns the builds saved by the user, excluding live builds.
+func (m AvatarBuildsMap) OnlySaved() AvatarBuildsMap {
+	return m.Filter(func(b Build) bool { return !b.Live })
+}
+
+// PublicOnly returns the public builds. Characters left without public builds are
+// omitted from the result.
+func (m AvatarBuildsMap) PublicOnly() AvatarBuildsMap {
+	return m.Filter(func(b Build) bool { return b.Public })
+}
+
+// Count returns the total number of builds across all characters.
+func (m AvatarBuildsMap) Count() int {
+	count := 0
+	for _, builds := range m {
+		count += len(builds)
+	}
+	return count
+}
+
+// CountByAvatar returns the number of builds of each character, keyed by avatarID.
+func (m AvatarBuildsMap) CountByAvatar() map[string]int {
+	counts := make(map[string]int, len(m))
+	for avatarID, builds := range m {
+		counts[avatarID] = len(builds)
+	}
+	return counts
+}
+
 // AvatarDataWrapper is a container struct that holds character data from different game clients.
 // It is designed to support multiple games while maintaining a unified interface.
 type AvatarDataWrapper struct {
@@ -44,35 +354,42 @@ type AvatarDataWrapper struct {
 	Raw     json.RawMessage     `json:"-"`                 // Raw contains the original JSON data for custom unmarshaling or debugging purposes
 }
 
-// UnmarshalJSON implements the json.Unmarshaler interface to handle custom JSON unmarshaling
-// for AvatarDataWrapper. This method populates the appropriate game-specific avatar data
-// field (Genshin, HSR, or ZZZ) based on the incoming JSON structure.
-//
-// The method attempts to unmarshal the input JSON into the Raw field first, followed by
-// each game-specific field (Genshin, HSR, ZZZ). It returns an error if any unmarshaling
-// attempt fails, leaving unmatched fields as nil. The Raw field preserves the original
-// JSON data for custom processing or debugging.
+// UnmarshalJSON implements the json.Unmarshaler interface for AvatarDataWrapper. It only
+// stores a copy of the input in Raw: the avatar data itself does not tell which game it
+// belongs to, and the game structs share field names, so decoding it into each of them
+// would leave several fields populated. When the wrapper is decoded as part of a Build,
+// Build.UnmarshalJSON then fills the single game field matching the build's HoyoType.
 //
 // Parameters:
 //   - data: The JSON-encoded byte slice containing the avatar data.
 //
 // Returns:
-//   - error: An error if unmarshaling fails for the Raw field or any game-specific field.
+//   - error: Always nil; the data is only stored.
 func (a *AvatarDataWrapper) UnmarshalJSON(data []byte) error {
-	if err := json.Unmarshal(data, &a.Raw); err != nil {
-		return err
-	}
-
-	if err := json.Unmarshal(data, &a.Genshin); err != nil {
-		return err
-	}
+	a.Genshin, a.HSR, a.ZZZ = nil, nil, nil
+	a.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
 
-	if err := json.Unmarshal(data, &a.HSR); err != nil {
-		return err
+// decode decodes Raw into the game field matching hoyoType (0 for Genshin, 1 for HSR,
+// 2 for ZZZ), leaving the others nil. Avatar data of an unknown game, or null, is only
+// kept in Raw.
+func (a *AvatarDataWrapper) decode(hoyoType int) error {
+	a.Genshin, a.HSR, a.ZZZ = nil, nil, nil
+	if len(a.Raw) == 0 || bytes.Equal(a.Raw, []byte("null")) {
+		return nil
 	}
 
-	if err := json.Unmarshal(data, &a.ZZZ); err != nil {
-		return err
+	switch hoyoType {
+	case 0:
+		a.Genshin = new(genshin.AvatarInfo)
+		return json.Unmarshal(a.Raw, a.Genshin)
+	case 1:
+		a.HSR = new(hsr.AvatarDetail)
+		return json.Unmarshal(a.Raw, a.HSR)
+	case 2:
+		a.ZZZ = new(zzz.AvatarData)
+		return json.Unmarshal(a.Raw, a.ZZZ)
 	}
 
 	return nil
@@ -84,12 +401,14 @@ func (a *AvatarDataWrapper) UnmarshalJSON(data []byte) error {
 //
 // The method checks each game-specific field in order of priority (Genshin -> HSR -> ZZZ)
 // and returns the JSON representation of the first non-nil field it encounters. If no
-// game-specific data is present, it returns (nil, nil).
+// game-specific data is present, e.g. for a build of a game the library does not know
+// yet, Raw is returned as is, so the data round-trips unchanged. A wrapper without any
+// data is serialized as null.
 //
 // Returns:
-//   - []byte: The JSON-encoded data of the populated game-specific field
+//   - []byte: The JSON-encoded data of the populated game-specific field, or Raw
 //   - error: Returns an error if marshaling fails, or nil if successful
-func (a *AvatarDataWrapper) MarshalJSON() ([]byte, error) {
+func (a AvatarDataWrapper) MarshalJSON() ([]byte, error) {
 	if a.Genshin != nil {
 		return json.Marshal(a.Genshin)
 	}
@@ -102,7 +421,11 @@ func (a *AvatarDataWrapper) MarshalJSON() ([]byte, error) {
 		return json.Marshal(a.ZZZ)
 	}
 
-	return nil, nil
+	if len(a.Raw) > 0 {
+		return a.Raw, nil
+	}
+
+	return []byte("null"), nil
 }
 
 // Hoyos is a map of Hoyo accounts and their metadata. The endpoint returns only
@@ -112,6 +435,33 @@ func (a *AvatarDataWrapper) MarshalJSON() ([]byte, error) {
 // that game account.
 type Hoyos map[string]Hoyo
 
+// UIDsByGame returns the UIDs of the accounts grouped by their HoyoType (0 for Genshin,
+// 1 for HSR, 2 for ZZZ). The UIDs of each game are sorted in ascending order. Accounts
+// whose UID is hidden are omitted.
+func (h Hoyos) UIDsByGame() map[int][]int {
+	uids := make(map[int][]int)
+	for _, hoyo := range h {
+		if hoyo.UID == 0 {
+			continue
+		}
+		uids[hoyo.HoyoType] = append(uids[hoyo.HoyoType], hoyo.UID)
+	}
+	for _, list := range uids {
+		sort.Ints(list)
+	}
+	return uids
+}
+
+// UIDForHash returns the UID of the account with the given hash. It returns false if
+// there is no such account or its UID is hidden.
+func (h Hoyos) UIDForHash(hash string) (int, bool) {
+	hoyo, ok := h[hash]
+	if !ok || hoyo.UID == 0 {
+		return 0, false
+	}
+	return hoyo.UID, true
+}
+
 // Hoyo contains information about a specific Hoyo account.
 type Hoyo struct {
 	User         *Owner             `json:"user,omitempty"`           // User information
@@ -129,27 +479,30 @@ type Hoyo struct {
 	LiveDataHash int                `json:"live_data_hash,omitempty"` // Hash of the live data for the account
 }
 
-// Settings represents build-specific configuration options.
-type Settings struct {
-	AdaptiveColor *bool    `json:"adaptiveColor,omitempty"` // Whether adaptive color is enabled
-	ArtSource     *string  `json:"artSource,omitempty"`     // Source of the image
-	Caption       *string  `json:"caption,omitempty"`       // Caption of the build
-	HonkardWidth  *float64 `json:"honkardWidth,omitempty"`  // Width of the image
-	Transform     *string  `json:"transform,omitempty"`     // Transformation applied to the image
-}
+// OrderedAvatarIDs returns the avatarIDs of AvatarOrder sorted by their position, which
+// is the order of the characters the user set on Enka. It returns nil if the account
+// has no avatar order. To sort the builds of the account the same way, use
+// AvatarBuildsMap.InAvatarOrder with AvatarOrder.
+func (h *Hoyo) OrderedAvatarIDs() []string {
+	if len(h.AvatarOrder) == 0 {
+		return nil
+	}
 
-// Owner represents an EnkaNetwork user profile associated with a game account.
-type Owner struct {
-	ID       int             `json:"id,omitempty"`       // User ID
-	Hash     string          `json:"hash,omitempty"`     // User hash
-	Username string          `json:"username,omitempty"` // Enka username
-	Profile  *PatreonProfile `json:"profile,omitempty"`  // Patreon profile data for Patreon members
+	avatarIDs := make([]string, 0, len(h.AvatarOrder))
+	for avatarID := range h.AvatarOrder {
+		avatarIDs = append(avatarIDs, avatarID)
+	}
+	sortAvatarIDs(avatarIDs, h.AvatarOrder)
+	return avatarIDs
 }
 
+// Settings represents the display settings of a build. It is the same type in all the
+// game packages.
+type Settings = models.BuildSettings
+
+// Owner represents an EnkaNetwork user profile. It is the same type as the Owner of the
+// game profiles, so a value can be passed between the packages.
+type Owner = models.Owner
+
 // PatreonProfile contains Patreon-related information for an Enka user.
-type PatreonProfile struct {
-	Bio      string `json:"bio,omitempty"`       // User bio from Patreon
-	Level    int    `json:"level,omitempty"`     // Patreon membership level
-	Avatar   string `json:"avatar,omitempty"`    // Profile picture on Enka
-	ImageURL string `json:"image_url,omitempty"` // Profile picture from Patreon
-}
+type PatreonProfile = models.PatreonProfile
@@ -0,0 +1,292 @@
+package enka
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestAvatarBuildsMapSort checks that shuffled builds are sorted by order, with live builds last.
+func TestAvatarBuildsMapSort(t *testing.T) {
+	want := []Build{
+		{ID: 4, Order: "1"},
+		{ID: 2, Order: "2"},
+		{ID: 5, Order: "2"},
+		{ID: 1, Order: "10"},
+		{ID: 3, Order: "0", Live: true},
+		{ID: 6, Order: "3", Live: true},
+	}
+
+	for seed := range int64(10) {
+		builds := make([]Build, len(want))
+		copy(builds, want)
+		rand.New(rand.NewSource(seed)).Shuffle(len(builds), func(i, j int) {
+			builds[i], builds[j] = builds[j], builds[i]
+		})
+
+		got := AvatarBuildsMap{"10000002": builds}.Sort()
+		if !reflect.DeepEqual(got["10000002"], want) {
+			t.Errorf("seed %d: got %v, want %v", seed, got["10000002"], want)
+		}
+	}
+}
+
+// errInvalid stands for any error other than ErrTruncatedResponse in TestDecodeBuilds.
+var errInvalid = errors.New("invalid")
+
+// TestDecodeBuilds checks that builds are yielded in response order, iteration can stop
+// early, and malformed responses are reported by Err.
+func TestDecodeBuilds(t *testing.T) {
+	body := `{"10000002":[{"id":1,"hoyo_type":0},{"id":2,"hoyo_type":0}],"10000003":null,"1309":[{"id":3,"hoyo_type":1}]}`
+
+	tests := []struct {
+		name    string
+		body    string
+		limit   int
+		want    []string
+		wantErr error // Error wrapped by Err, or errInvalid for any error
+	}{
+		{"complete", body, 0, []string{"10000002/1", "10000002/2", "1309/3"}, nil},
+		{"break", body, 1, []string{"10000002/1"}, nil},
+		{"truncated", body[:len(body)-25], 0, []string{"10000002/1", "10000002/2"}, ErrTruncatedResponse},
+		{"missing end", body[:len(body)-1], 0, []string{"10000002/1", "10000002/2", "1309/3"}, ErrTruncatedResponse},
+		{"not an object", `[]`, 0, nil, errInvalid},
+	}
+
+	for _, tt := range tests {
+		it := &BuildsIterator{body: io.NopCloser(strings.NewReader(tt.body))}
+
+		var got []string
+		for avatarID, build := range it.All() {
+			got = append(got, fmt.Sprintf("%s/%d", avatarID, build.ID))
+			if len(got) == tt.limit {
+				break
+			}
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got builds %v, want %v", tt.name, got, tt.want)
+		}
+
+		err := it.Err()
+		switch tt.wantErr {
+		case nil:
+			if err != nil {
+				t.Errorf("%s: Err() = %v, want nil", tt.name, err)
+			}
+		case errInvalid:
+			if err == nil || errors.Is(err, ErrTruncatedResponse) {
+				t.Errorf("%s: Err() = %v, want a decoding error", tt.name, err)
+			}
+		default:
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: Err() = %v, want %v", tt.name, err, tt.wantErr)
+			}
+		}
+	}
+}
+
+// TestAvatarOrder checks that avatars are sorted by their position, with unordered ones last.
+func TestAvatarOrder(t *testing.T) {
+	hoyo := &Hoyo{AvatarOrder: map[string]int{"10000089": 2, "10000002": 0, "10000046": 1}}
+	builds := AvatarBuildsMap{"10000046": nil, "10000100": nil, "10000089": nil, "10000021": nil}
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{"OrderedAvatarIDs", hoyo.OrderedAvatarIDs(), []string{"10000002", "10000046", "10000089"}},
+		{"InAvatarOrder", builds.InAvatarOrder(hoyo.AvatarOrder), []string{"10000046", "10000089", "10000021", "10000100"}},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+// TestHoyosUIDs checks that UIDs are grouped by game and looked up by hash.
+func TestHoyosUIDs(t *testing.T) {
+	hoyos := Hoyos{
+		"a": {UID: 800000002, HoyoType: 0},
+		"b": {UID: 600000001, HoyoType: 1},
+		"c": {UID: 700000001, HoyoType: 0},
+		"d": {HoyoType: 2},
+	}
+
+	want := map[int][]int{0: {700000001, 800000002}, 1: {600000001}}
+	if got := hoyos.UIDsByGame(); !reflect.DeepEqual(got, want) {
+		t.Errorf("UIDsByGame() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		hash string
+		uid  int
+		ok   bool
+	}{
+		{"b", 600000001, true},
+		{"d", 0, false},
+		{"z", 0, false},
+	}
+	for _, tt := range tests {
+		if uid, ok := hoyos.UIDForHash(tt.hash); uid != tt.uid || ok != tt.ok {
+			t.Errorf("UIDForHash(%q) = %d, %v, want %d, %v", tt.hash, uid, ok, tt.uid, tt.ok)
+		}
+	}
+}
+
+// TestAvatarDataWrapperMarshalRaw checks that avatar data of an unknown game is serialized from Raw.
+func TestAvatarDataWrapperMarshalRaw(t *testing.T) {
+	tests := []struct {
+		name  string
+		build Build
+		want  string
+	}{
+		{"unknown game", Build{ID: 1, HoyoType: 9, AvatarData: AvatarDataWrapper{Raw: json.RawMessage(`{"unknownField":1}`)}}, `{"id":1,"avatar_data":{"unknownField":1},"settings":{},"hoyo_type":9}`},
+		{"empty", Build{ID: 2}, `{"id":2,"avatar_data":null,"settings":{},"hoyo_type":0}`},
+	}
+
+	for _, tt := range tests {
+		got, err := json.Marshal(tt.build)
+		if err != nil {
+			t.Fatalf("%s: failed to encode build: %v", tt.name, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s: json.Marshal() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestBuildUnmarshalByHoyoType checks that avatar data is decoded only into the game of the build.
+func TestBuildUnmarshalByHoyoType(t *testing.T) {
+	tests := []struct {
+		name              string
+		data              string
+		genshin, hsr, zzz bool
+	}{
+		{"genshin", `{"id":1,"hoyo_type":0,"avatar_data":{"avatarId":10000089}}`, true, false, false},
+		{"hsr", `{"id":2,"avatar_data":{"avatarId":1309,"level":80},"hoyo_type":1}`, false, true, false},
+		{"zzz", `{"id":3,"hoyo_type":2,"avatar_data":{"Id":1191,"Level":60}}`, false, false, true},
+		{"unknown game", `{"id":4,"hoyo_type":9,"avatar_data":{"unknownField":1}}`, false, false, false},
+	}
+
+	for _, tt := range tests {
+		var build Build
+		if err := json.Unmarshal([]byte(tt.data), &build); err != nil {
+			t.Fatalf("%s: failed to decode build: %v", tt.name, err)
+		}
+
+		data := build.AvatarData
+		if (data.Genshin != nil) != tt.genshin || (data.HSR != nil) != tt.hsr || (data.ZZZ != nil) != tt.zzz {
+			t.Errorf("%s: populated = %v %v %v, want %v %v %v", tt.name,
+				data.Genshin != nil, data.HSR != nil, data.ZZZ != nil, tt.genshin, tt.hsr, tt.zzz)
+		}
+	}
+
+	var build Build
+	if err := json.Unmarshal([]byte(tests[3].data), &build); err != nil {
+		t.Fatalf("failed to decode build: %v", err)
+	}
+	encoded, err := json.Marshal(build)
+	if err != nil {
+		t.Fatalf("failed to encode build: %v", err)
+	}
+	if want := `{"id":4,"avatar_data":{"unknownField":1},"settings":{},"hoyo_type":9}`; string(encoded) != want {
+		t.Errorf("json.Marshal() = %s, want %s", encoded, want)
+	}
+}
+
+// testBuildsMap returns builds of Genshin Impact and Honkai: Star Rail characters, mixing
+// live, saved, public and private builds.
+func testBuildsMap() AvatarBuildsMap {
+	return AvatarBuildsMap{
+		"10000089": {
+			{ID: 1, HoyoType: 0, Live: true, Public: true},
+			{ID: 2, HoyoType: 0, Public: true},
+			{ID: 3, HoyoType: 0},
+		},
+		"10000002": {
+			{ID: 4, HoyoType: 0, Live: true},
+		},
+		"1310": {
+			{ID: 5, HoyoType: 1, Public: true},
+		},
+	}
+}
+
+// buildIDs returns the IDs of the builds of each character, to compare filtered maps.
+func buildIDs(m AvatarBuildsMap) map[string][]int {
+	ids := make(map[string][]int, len(m))
+	for avatarID, builds := range m {
+		for _, build := range builds {
+			ids[avatarID] = append(ids[avatarID], build.ID)
+		}
+	}
+	return ids
+}
+
+// TestAvatarBuildsMapFilter checks the hoyo type, live and saved filters, and that
+// characters left without builds are omitted and the original map is not modified.
+func TestAvatarBuildsMapFilter(t *testing.T) {
+	m := testBuildsMap()
+
+	tests := []struct {
+		name string
+		got  AvatarBuildsMap
+		want map[string][]int
+	}{
+		{"FilterByHoyoType(0)", m.FilterByHoyoType(0), map[string][]int{"10000089": {1, 2, 3}, "10000002": {4}}},
+		{"FilterByHoyoType(1)", m.FilterByHoyoType(1), map[string][]int{"1310": {5}}},
+		{"FilterByHoyoType(2)", m.FilterByHoyoType(2), map[string][]int{}},
+		{"OnlyLive", m.OnlyLive(), map[string][]int{"10000089": {1}, "10000002": {4}}},
+		{"OnlySaved", m.OnlySaved(), map[string][]int{"10000089": {2, 3}, "1310": {5}}},
+		{"OnlySaved().FilterByHoyoType(0)", m.OnlySaved().FilterByHoyoType(0), map[string][]int{"10000089": {2, 3}}},
+		{"OnlyLive on empty map", AvatarBuildsMap{}.OnlyLive(), map[string][]int{}},
+	}
+
+	for _, tt := range tests {
+		if got := buildIDs(tt.got); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if got, want := buildIDs(m), buildIDs(testBuildsMap()); !reflect.DeepEqual(got, want) {
+		t.Errorf("original map modified: %v, want %v", got, want)
+	}
+}
+
+// TestAvatarBuildsMapCount checks PublicOnly, Count and CountByAvatar, including on an empty map.
+func TestAvatarBuildsMapCount(t *testing.T) {
+	m := testBuildsMap()
+
+	if got, want := buildIDs(m.PublicOnly()), map[string][]int{"10000089": {1, 2}, "1310": {5}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PublicOnly() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name     string
+		m        AvatarBuildsMap
+		count    int
+		byAvatar map[string]int
+	}{
+		{"all", m, 5, map[string]int{"10000089": 3, "10000002": 1, "1310": 1}},
+		{"public", m.PublicOnly(), 3, map[string]int{"10000089": 2, "1310": 1}},
+		{"empty", AvatarBuildsMap{}, 0, map[string]int{}},
+		{"nil", nil, 0, map[string]int{}},
+	}
+
+	for _, tt := range tests {
+		if got := tt.m.Count(); got != tt.count {
+			t.Errorf("%s: Count() = %d, want %d", tt.name, got, tt.count)
+		}
+		if got := tt.m.CountByAvatar(); !reflect.DeepEqual(got, tt.byAvatar) {
+			t.Errorf("%s: CountByAvatar() = %v, want %v", tt.name, got, tt.byAvatar)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package enka
+
+import (
+	"net/url"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+)
+
+// ProfileURL returns the URL of the EnkaNetwork page of the user username (e.g.,
+// "https://enka.network/u/Algoinde/"). It returns an empty string if username is not a
+// valid username (see IsValidUsername). Non-ASCII usernames are percent-encoded.
+func ProfileURL(username string) string {
+	if !IsValidUsername(username) {
+		return ""
+	}
+	return core.WebURL + "/u/" + url.PathEscape(username) + "/"
+}
+
+// HoyoURL returns the URL of the EnkaNetwork page of the game account hash linked to the
+// user username (e.g., "https://enka.network/u/Algoinde/4Wjv2e/"). It returns an empty
+// string if username or hash is not valid (see IsValidUsername and IsValidHoyoHash).
+func HoyoURL(username, hash string) string {
+	if !IsValidUsername(username) || !IsValidHoyoHash(hash) {
+		return ""
+	}
+	return ProfileURL(username) + hash + "/"
+}
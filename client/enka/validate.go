@@ -0,0 +1,28 @@
+package enka
+
+import "github.com/kirinyoku/enkanetwork-go/internal/core"
+
+// IsValidUsername reports whether username is a valid EnkaNetwork username.
+//
+// EnkaNetwork usernames follow these rules:
+//   - They are 1 to 150 characters long.
+//   - They consist only of letters, digits and the characters '@', '.', '+', '-' and '_'.
+//   - They are not "." or "..".
+//
+// The client methods taking a username use this function to return ErrInvalidUsername
+// without making a request for input that can never match an existing user.
+func IsValidUsername(username string) bool {
+	return core.IsValidUsername(username)
+}
+
+// IsValidHoyoHash reports whether hash is a valid hoyo hash, the identifier of a game
+// account linked to an EnkaNetwork profile as returned by GetUserProfileHoyos.
+//
+// Hoyo hashes are short strings of ASCII letters and digits, such as "4Wjv2e". Any hash
+// of 1 to 16 such characters is accepted.
+//
+// The client methods taking a hoyo hash use this function to return ErrInvalidHoyoHash
+// without making a request, e.g. when a full URL is passed by mistake.
+func IsValidHoyoHash(hash string) bool {
+	return core.IsValidHoyoHash(hash)
+}
@@ -0,0 +1,73 @@
+package enka
+
+import "testing"
+
+// TestIsValidUsername checks IsValidUsername against valid and invalid usernames.
+func TestIsValidUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		want     bool
+	}{
+		{"Algoinde", true},
+		{"user_name-1", true},
+		{"john.doe+enka@example", true},
+		{"ユーザー", true},
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"user name", false},
+		{"user/name", false},
+		{"https://enka.network/u/Algoinde", false},
+		{"user?info", false},
+		{string(make([]byte, 151)), false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidUsername(tt.username); got != tt.want {
+			t.Errorf("IsValidUsername(%q) = %v, want %v", tt.username, got, tt.want)
+		}
+	}
+}
+
+// TestIsValidHoyoHash checks IsValidHoyoHash against valid and invalid hashes.
+func TestIsValidHoyoHash(t *testing.T) {
+	tests := []struct {
+		hash string
+		want bool
+	}{
+		{"4Wjv2e", true},
+		{"abc123", true},
+		{"", false},
+		{"4Wjv2e/builds", false},
+		{"https://enka.network/u/Algoinde/4Wjv2e", false},
+		{"4Wjv-2e", false},
+		{"abcdefghijklmnopq", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidHoyoHash(tt.hash); got != tt.want {
+			t.Errorf("IsValidHoyoHash(%q) = %v, want %v", tt.hash, got, tt.want)
+		}
+	}
+}
+
+// TestProfileURL checks the EnkaNetwork page URLs of users and their game accounts.
+func TestProfileURL(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"profile", ProfileURL("Algoinde"), "https://enka.network/u/Algoinde/"},
+		{"non-ASCII profile", ProfileURL("ユーザー"), "https://enka.network/u/%E3%83%A6%E3%83%BC%E3%82%B6%E3%83%BC/"},
+		{"invalid profile", ProfileURL("user/name"), ""},
+		{"hoyo", HoyoURL("Algoinde", "4Wjv2e"), "https://enka.network/u/Algoinde/4Wjv2e/"},
+		{"invalid hoyo", HoyoURL("Algoinde", "4Wjv2e/builds"), ""},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package genshin
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strconv"
+	"sync"
+)
+
+// assetsURL is the root URL of the images hosted by EnkaNetwork. An image is available
+// at assetsURL/<icon name>.png.
+const assetsURL = "https://enka.network/ui"
+
+// profileIconsJSON maps character IDs to the names of their profile picture icons.
+//
+// To add new characters, take the SideIconName of each character from
+// https://github.com/EnkaNetwork/API-docs/blob/master/store/characters.json and remove
+// its "_Side" part, e.g. "UI_AvatarIcon_Side_Furina" becomes "UI_AvatarIcon_Furina".
+//
+//go:embed assets/profile_icons.json
+var profileIconsJSON []byte
+
+// namecardsJSON maps namecard IDs to the names of their icons.
+//
+// To add new namecards, take the icon of each namecard from
+// https://github.com/EnkaNetwork/API-docs/blob/master/store/namecards.json.
+//
+//go:embed assets/namecards.json
+var namecardsJSON []byte
+
+// profileIcons returns the decoded profileIconsJSON. It is decoded on first use.
+var profileIcons = sync.OnceValue(func() map[string]string {
+	return decodeAssetTable("profile_icons.json", profileIconsJSON)
+})
+
+// namecards returns the decoded namecardsJSON. It is decoded on first use.
+var namecards = sync.OnceValue(func() map[string]string {
+	return decodeAssetTable("namecards.json", namecardsJSON)
+})
+
+// decodeAssetTable decodes an embedded table mapping IDs to icon names. The tables are
+// part of the library, so an invalid table is a programming error and panics.
+func decodeAssetTable(name string, data []byte) map[string]string {
+	var table map[string]string
+	if err := json.Unmarshal(data, &table); err != nil {
+		panic("genshin: invalid assets/" + name + ": " + err.Error())
+	}
+	return table
+}
+
+// IconURL returns the URL of the image with the given icon name on EnkaNetwork, such as
+// the Icon of FlatWeapon and FlatReliquary (e.g., "UI_EquipIcon_Sword_Regalia"). It
+// returns an empty string if iconName is empty.
+func IconURL(iconName string) string {
+	if iconName == "" {
+		return ""
+	}
+	return assetsURL + "/" + iconName + ".png"
+}
+
+// ProfileIconURL returns the URL of the profile picture of the given character, as
+// found in PlayerInfo.ProfilePicture.AvatarID. It returns an empty string and false if
+// the character is not known to this version of the library, e.g. because it was added
+// to the game after the release.
+//
+// Example:
+//
+//	if picture := profile.PlayerInfo.ProfilePicture; picture != nil {
+//	    if url, ok := genshin.ProfileIconURL(picture.AvatarID); ok {
+//	        fmt.Println("Profile picture:", url)
+//	    }
+//	}
+func ProfileIconURL(avatarID int) (string, bool) {
+	icon, ok := profileIcons()[strconv.Itoa(avatarID)]
+	if !ok {
+		return "", false
+	}
+	return IconURL(icon), true
+}
+
+// NamecardURL returns the URL of the image of the given namecard, as found in
+// PlayerInfo.NameCardId and PlayerInfo.ShowNameCardIdList. It returns an empty string
+// and false if the namecard is not known to this version of the library.
+//
+// Example:
+//
+//	for _, id := range profile.PlayerInfo.ShowNameCardIdList {
+//	    if url, ok := genshin.NamecardURL(id); ok {
+//	        fmt.Println("Namecard:", url)
+//	    }
+//	}
+func NamecardURL(id int) (string, bool) {
+	icon, ok := namecards()[strconv.Itoa(id)]
+	if !ok {
+		return "", false
+	}
+	return IconURL(icon), true
+}
@@ -0,0 +1,74 @@
+package genshin
+
+import "strconv"
+
+// ConstellationLevel returns the character's constellation level (0-6). TalentIDList
+// contains one entry per unlocked constellation, so the level is its length.
+func (a *AvatarInfo) ConstellationLevel() int {
+	return len(a.TalentIDList)
+}
+
+// TalentLevel returns the level of the character's skill with the given ID, including
+// the extra levels granted by constellations (e.g., the 3rd and 5th).
+//
+// The base level is read from SkillLevelMap, which is keyed by skill ID. The extra levels
+// are read from ProudSkillExtraLevelMap, which is keyed by the proud skill group ID of the
+// skill instead. The API response does not link the two, so the group ID must be taken
+// from the game data, e.g. the "ProudMap" of the character in Enka's characters.json.
+// Pass 0 as proudSkillGroupID to get the base level only.
+//
+// It returns 0 if the character has no skill with the given ID.
+func (a *AvatarInfo) TalentLevel(skillID, proudSkillGroupID int) int {
+	level := a.SkillLevelMap[strconv.Itoa(skillID)]
+	if level == 0 || proudSkillGroupID == 0 {
+		return level
+	}
+	return level + a.ProudSkillExtraLevelMap[strconv.Itoa(proudSkillGroupID)]
+}
+
+// Keys of AvatarInfo.PropMap, which holds the character's progression as strings.
+const (
+	PropExperience = 1001 // Experience towards the next level
+	PropAscension  = 1002 // Ascension phase (0-6)
+	PropLevel      = 4001 // Character level (1-90)
+)
+
+// Level returns the character's level, read from the PropLevel entry of PropMap. It
+// returns 0 if the entry is missing or not a number.
+func (a *AvatarInfo) Level() int {
+	return a.prop(PropLevel)
+}
+
+// AscensionPhase returns the character's ascension phase (0-6), read from the
+// PropAscension entry of PropMap. The API omits the entry for characters that have not
+// ascended yet, so it returns 0 if the entry is missing or not a number.
+func (a *AvatarInfo) AscensionPhase() int {
+	return a.prop(PropAscension)
+}
+
+// Experience returns the character's experience towards the next level, read from the
+// PropExperience entry of PropMap. It returns 0 if the entry is missing or not a number,
+// which is also the case for characters at the maximum level of their ascension phase.
+func (a *AvatarInfo) Experience() int {
+	return a.prop(PropExperience)
+}
+
+// prop returns the value of the PropMap entry with the given key as an integer, or 0 if
+// the entry is missing or not a number.
+func (a *AvatarInfo) prop(key int) int {
+	value, err := strconv.Atoi(a.PropMap[strconv.Itoa(key)].Val)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// RefinementLevel returns the weapon's refinement level (1-5). AffixMap stores the
+// refinement as a value from 0 to 4, so 1 is added to match the R1-R5 notation used in
+// game. It returns 0 if the weapon has no refinement data.
+func (w *Weapon) RefinementLevel() int {
+	for _, affix := range w.AffixMap {
+		return affix + 1
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+package genshin
+
+import "testing"
+
+// TestTalentLevel checks the base level, the extra levels granted by constellations and
+// the levels of missing skills.
+func TestTalentLevel(t *testing.T) {
+	character := &AvatarInfo{
+		SkillLevelMap:           map[string]int{"10891": 9, "10892": 10, "10895": 8},
+		ProudSkillExtraLevelMap: map[string]int{"8932": 3},
+	}
+
+	tests := []struct {
+		name              string
+		skillID           int
+		proudSkillGroupID int
+		want              int
+	}{
+		{"base level", 10892, 0, 10},
+		{"boosted", 10892, 8932, 13},
+		{"not boosted", 10895, 8939, 8},
+		{"missing skill", 10899, 0, 0},
+		{"missing skill with boost", 10899, 8932, 0},
+	}
+
+	for _, tt := range tests {
+		if got := character.TalentLevel(tt.skillID, tt.proudSkillGroupID); got != tt.want {
+			t.Errorf("%s: TalentLevel(%d, %d) = %d, want %d", tt.name, tt.skillID, tt.proudSkillGroupID, got, tt.want)
+		}
+	}
+
+	if got := (&AvatarInfo{}).TalentLevel(10891, 8931); got != 0 {
+		t.Errorf("TalentLevel() without skills = %d, want 0", got)
+	}
+}
@@ -1,158 +1,289 @@
-//go:build integration
-// +build integration
-
-// export RUN_INTEGRATION_TESTS=true
-// go test -v ./client/genshin -tags=integration
-
 package genshin
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
-	"io"
+	"errors"
 	"net/http"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
-	"github.com/kirinyoku/enkanetwork-go/internal/core"
+	"github.com/kirinyoku/enkanetwork-go/cache"
+	"github.com/kirinyoku/enkanetwork-go/internal/testutil"
 )
 
-// TestMain sets up any global state for the integration tests.
-func TestMain(m *testing.M) {
-	os.Exit(m.Run())
-}
+// TestGetProfileBypassCache checks that WithBypassCache skips the cached profile and caches the fresh one.
+func TestGetProfileBypassCache(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
 
-// TestGetProfileNotFound ensures GetProfile returns ErrPlayerNotFound for a non-existent UID.
-func TestGetProfileNotFound(t *testing.T) {
-	if os.Getenv("RUN_INTEGRATION_TESTS") != "true" {
-		t.Skip("skipping integration test; set RUN_INTEGRATION_TESTS=true to run")
+	var requests int
+	doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		requests++
+		return testutil.Response(req, http.StatusOK, string(data)), nil
+	})
+
+	c := cache.NewLRU(10)
+	defer c.Close()
+	client := New(WithCache(c), WithHTTPDoer(doer))
+	ctx := context.Background()
+
+	cached, err := client.GetProfile(ctx, "618285856")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fresh, err := client.GetProfile(WithBypassCache(ctx), "618285856")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fresh == cached || requests != 2 {
+		t.Errorf("expected a fresh profile from a second request, got %d requests", requests)
 	}
 
-	client := NewClient(nil, nil, "test-agent")
-	_, err := client.GetProfile(context.Background(), "987654321")
-	if err != ErrPlayerNotFound {
-		t.Errorf("expected ErrPlayerNotFound, got %v", err)
+	again, err := client.GetProfile(ctx, "618285856")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again != fresh || requests != 2 {
+		t.Errorf("expected the fresh profile from the cache, got %d requests", requests)
 	}
 }
 
-// TestGetPlayerInfoInvalidUID checks that GetPlayerInfo returns ErrInvalidUIDFormat for an invalid UID.
-func TestGetPlayerInfoInvalidUID(t *testing.T) {
-	if os.Getenv("RUN_INTEGRATION_TESTS") != "true" {
-		t.Skip("skipping integration test; set RUN_INTEGRATION_TESTS=true to run")
+// TestGetProfileServeStaleOnError checks that the cached profile is returned with ErrStaleData when a refresh fails.
+func TestGetProfileServeStaleOnError(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
 	}
 
-	client := NewClient(nil, nil, "test-agent")
-	_, err := client.GetPlayerInfo(context.Background(), "123")
-	if err != ErrInvalidUIDFormat {
-		t.Errorf("expected ErrInvalidUIDFormat, got %v", err)
+	status := http.StatusOK
+	doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		return testutil.Response(req, status, string(data)), nil
+	})
+
+	c := cache.NewLRU(10)
+	defer c.Close()
+	client := New(WithCache(c), WithHTTPDoer(doer), WithNoRetry(), WithServeStaleOnError())
+	ctx := WithBypassCache(context.Background())
+
+	cached, err := client.GetProfile(ctx, "618285856")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-}
 
-// TestGetPlayerInfoNotFound ensures GetPlayerInfo returns ErrPlayerNotFound for a non-existent UID.
-func TestGetPlayerInfoNotFound(t *testing.T) {
-	if os.Getenv("RUN_INTEGRATION_TESTS") != "true" {
-		t.Skip("skipping integration test; set RUN_INTEGRATION_TESTS=true to run")
+	status = http.StatusInternalServerError
+	stale, err := client.GetProfile(ctx, "618285856")
+	if !errors.Is(err, ErrStaleData) || !errors.Is(err, ErrServerError) {
+		t.Errorf("expected ErrStaleData wrapping ErrServerError, got %v", err)
+	}
+	if stale != cached {
+		t.Error("expected the cached profile to be returned")
 	}
 
-	client := NewClient(nil, nil, "test-agent")
-	_, err := client.GetPlayerInfo(context.Background(), "987654321")
-	if err != ErrPlayerNotFound {
-		t.Errorf("expected ErrPlayerNotFound, got %v", err)
+	status = http.StatusNotFound
+	if profile, err := client.GetProfile(ctx, "618285856"); !errors.Is(err, ErrPlayerNotFound) || profile != nil {
+		t.Errorf("expected ErrPlayerNotFound without stale data, got %v, %v", profile, err)
 	}
 }
 
-// TestGetProfile ensures that the JSON response from the API matches the JSON
-// generated from the Go structure returned by the client GetProfile method.
-func TestGetProfile(t *testing.T) {
-	if os.Getenv("RUN_INTEGRATION_TESTS") != "true" {
-		t.Skip("skipping integration test; set RUN_INTEGRATION_TESTS=true to run")
+// TestGetProfileNotFoundTTL checks that WithNotFoundTTL caches ErrPlayerNotFound but not
+// other errors.
+func TestGetProfileNotFoundTTL(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		want     error
+		requests int
+	}{
+		{"not found", http.StatusNotFound, "", ErrPlayerNotFound, 1},
+		{"not cached yet", http.StatusNotFound, `{"ttl": 30}`, ErrProfileNotCachedYet, 2},
+		{"server error", http.StatusInternalServerError, "", ErrServerError, 2},
 	}
 
-	ctx := context.Background()
-	uid := "618285856"
-	client := NewClient(nil, nil, "test-agent")
+	for _, tt := range tests {
+		var requests int
+		doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return testutil.Response(req, tt.status, tt.body), nil
+		})
 
-	profile, err := client.GetProfile(ctx, uid)
-	if err != nil {
-		t.Fatalf("failed to get profile from client: %v", err)
-	}
+		c := cache.NewLRU(10)
+		client := New(WithCache(c), WithHTTPDoer(doer), WithNoRetry(), WithNotFoundTTL(time.Minute))
 
-	clientJSON, err := json.Marshal(profile)
-	if err != nil {
-		t.Fatalf("failed to marshal client response to JSON: %v", err)
+		for range 2 {
+			if _, err := client.GetProfile(context.Background(), "618285856"); !errors.Is(err, tt.want) {
+				t.Errorf("%s: GetProfile() error = %v, want %v", tt.name, err, tt.want)
+			}
+		}
+		if requests != tt.requests {
+			t.Errorf("%s: got %d requests, want %d", tt.name, requests, tt.requests)
+		}
+		c.Close()
 	}
+}
 
-	url := fmt.Sprintf("https://enka.network/api/uid/%s", uid)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// TestGetProfileFileCache checks that a profile, or a not found result, cached in a
+// cache.FileCache is served after the cache is flushed and loaded again.
+func TestGetProfileFileCache(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
 	if err != nil {
-		t.Fatalf("failed to create HTTP request: %v", err)
+		t.Fatalf("failed to read fixture: %v", err)
 	}
-	req.Header.Set("User-Agent", "test-agent")
+	// Add a field the library does not model, which must survive in Extra
+	data = append(bytes.TrimSuffix(bytes.TrimSpace(data), []byte("}")), `,"newField":{"a":1}}`...)
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		t.Fatalf("failed to make HTTP request: %v", err)
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr error
+	}{
+		{"profile", http.StatusOK, string(data), nil},
+		{"not found", http.StatusNotFound, "", ErrPlayerNotFound},
 	}
-	defer resp.Body.Close()
 
-	apiJSON, err := io.ReadAll(resp.Body)
-	if err != nil {
-		t.Fatalf("failed to read API response: %v", err)
-	}
+	for _, tt := range tests {
+		var requests int
+		doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return testutil.Response(req, tt.status, tt.body), nil
+		})
 
-	apiJSON = core.RemoveTTLField(apiJSON)
-	clientJSON = core.RemoveTTLField(clientJSON)
+		path := filepath.Join(t.TempDir(), "cache.json")
+		var profiles [2]*Profile
+		for i := range profiles {
+			c, err := cache.NewFileCache(path)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			client := New(WithCache(c), WithHTTPDoer(doer), WithNotFoundTTL(time.Minute))
+			profiles[i], err = client.GetProfile(context.Background(), "618285856")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: lookup %d: error = %v, want %v", tt.name, i, err, tt.wantErr)
+			}
+			if err := client.Close(); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+		}
 
-	if !reflect.DeepEqual(apiJSON, clientJSON) {
-		t.Errorf("JSON responses do not match. API JSON: %s\nClient JSON: %s", apiJSON, clientJSON)
+		if requests != 1 {
+			t.Errorf("%s: expected the result from the file, got %d requests", tt.name, requests)
+		}
+		if !reflect.DeepEqual(profiles[1], profiles[0]) {
+			t.Errorf("%s: loaded profile differs from the fetched one:\n got %+v\nwant %+v", tt.name, profiles[1], profiles[0])
+		}
+		if tt.wantErr == nil {
+			if got := string(profiles[1].Extra["newField"]); got != `{"a":1}` {
+				t.Errorf("%s: Extra[newField] = %s, want %s", tt.name, got, `{"a":1}`)
+			}
+		}
 	}
 }
 
-// TestGetPlayerInfo ensures that the JSON response from the API matches the JSON
-// generated from the Go structure returned by the client GetPlayerInfo method.
-func TestGetPlayerInfo(t *testing.T) {
-	if os.Getenv("RUN_INTEGRATION_TESTS") != "true" {
-		t.Skip("skipping integration test; set RUN_INTEGRATION_TESTS=true to run")
-	}
+// TestGetBuilds checks that only Genshin Impact builds are returned, ordered by avatarID,
+// with the string order of the API decoded into Order.
+func TestGetBuilds(t *testing.T) {
+	body := `{
+		"10000089": [{"id": 1, "avatar_id": "10000089", "order": "2", "hoyo_type": 0}],
+		"1310": [{"id": 2, "avatar_id": "1310", "order": "1", "hoyo_type": 1}],
+		"10000002": [{"id": 3, "avatar_id": "10000002", "order": "10", "hoyo_type": 0}, {"id": 4, "avatar_id": "10000002", "order": "1", "live": true, "hoyo_type": 0}]
+	}`
 
-	ctx := context.Background()
-	uid := "618285856"
-	client := NewClient(nil, nil, "test-agent")
+	client := New(WithHTTPDoer(HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		return testutil.Response(req, http.StatusOK, body), nil
+	})))
 
-	profile, err := client.GetPlayerInfo(ctx, uid)
+	builds, err := client.GetBuilds(context.Background(), "Algoinde", "4Wjv2e")
 	if err != nil {
-		t.Fatalf("failed to get profile from client: %v", err)
+		t.Fatalf("unexpected error: %v", err)
 	}
 
-	clientJSON, err := json.Marshal(profile)
-	if err != nil {
-		t.Fatalf("failed to marshal client response to JSON: %v", err)
+	type build struct{ ID, Order int }
+	want := []build{{3, 10}, {4, 1}, {1, 2}}
+	got := make([]build, len(builds))
+	for i, b := range builds {
+		got[i] = build{b.ID, b.Order}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetBuilds() = %v, want %v", got, want)
 	}
+}
 
-	url := fmt.Sprintf("https://enka.network/api/uid/%s?info", uid)
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+// TestGetProfileRaw checks that the response body is returned unchanged and that error
+// statuses are mapped to the typed errors.
+func TestGetProfileRaw(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
 	if err != nil {
-		t.Fatalf("failed to create HTTP request: %v", err)
+		t.Fatalf("failed to read fixture: %v", err)
 	}
-	req.Header.Set("User-Agent", "test-agent")
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		t.Fatalf("failed to make HTTP request: %v", err)
+	tests := []struct {
+		status  int
+		want    []byte
+		wantErr error
+	}{
+		{http.StatusOK, data, nil},
+		{http.StatusBadRequest, nil, ErrInvalidUIDFormat},
+		{http.StatusNotFound, nil, ErrPlayerNotFound},
+		{http.StatusFailedDependency, nil, ErrServerMaintenance},
+		{http.StatusTooManyRequests, nil, ErrRateLimited},
+		{http.StatusInternalServerError, nil, ErrServerError},
+		{http.StatusServiceUnavailable, nil, ErrServiceUnavailable},
+		{http.StatusForbidden, nil, ErrUnexpectedStatus},
 	}
-	defer resp.Body.Close()
 
-	apiJSON, err := io.ReadAll(resp.Body)
-	if err != nil {
-		t.Fatalf("failed to read API response: %v", err)
+	for _, tt := range tests {
+		doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/api/uid/618285856" {
+				t.Errorf("unexpected path %q", req.URL.Path)
+			}
+			return testutil.Response(req, tt.status, string(tt.want)), nil
+		})
+
+		client := New(WithHTTPDoer(doer), WithNoRetry())
+		raw, err := client.GetProfileRaw(context.Background(), "618285856")
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("status %d: error = %v, want %v", tt.status, err, tt.wantErr)
+		}
+		if !bytes.Equal(raw, tt.want) {
+			t.Errorf("status %d: body = %q, want %q", tt.status, raw, tt.want)
+		}
+	}
+}
+
+// TestGetOwner checks that the owner of a linked profile is returned and that a profile
+// without an owner is reported with ErrNoOwner.
+func TestGetOwner(t *testing.T) {
+	tests := []struct {
+		name     string
+		body     string
+		username string
+		wantErr  error
+	}{
+		{"linked", `{"playerInfo":{"nickname":"Kirin"},"ttl":60,"uid":"618285856","owner":{"id":1,"hash":"4Wjv2e","username":"Algoinde"}}`, "Algoinde", nil},
+		{"not linked", `{"playerInfo":{"nickname":"Kirin"},"ttl":60,"uid":"618285856"}`, "", ErrNoOwner},
 	}
 
-	apiJSON = core.RemoveTTLField(apiJSON)
-	clientJSON = core.RemoveTTLField(clientJSON)
+	for _, tt := range tests {
+		client := New(WithHTTPDoer(HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			return testutil.Response(req, http.StatusOK, tt.body), nil
+		})))
 
-	if !reflect.DeepEqual(apiJSON, clientJSON) {
-		t.Errorf("JSON responses do not match. API JSON: %s\nClient JSON: %s", apiJSON, clientJSON)
+		owner, err := client.GetOwner(context.Background(), "618285856")
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if tt.wantErr != nil && owner != nil {
+			t.Errorf("%s: expected no owner, got %+v", tt.name, owner)
+		}
+		if tt.wantErr == nil && (owner == nil || owner.Username != tt.username) {
+			t.Errorf("%s: owner = %+v, want username %q", tt.name, owner, tt.username)
+		}
 	}
 }
@@ -0,0 +1,61 @@
+package genshin
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/kirinyoku/enkanetwork-go/cache"
+	"github.com/kirinyoku/enkanetwork-go/internal/testutil"
+)
+
+// TestGetProfileConcurrent fires concurrent requests through a single client sharing an
+// LRU cache, rate limiter and retry budget. Run it with -race to detect data races.
+func TestGetProfileConcurrent(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	var requests atomic.Int32
+	doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		requests.Add(1)
+		return testutil.Response(req, http.StatusOK, string(data)), nil
+	})
+
+	c := cache.NewLRU(100)
+	client := New(
+		WithHTTPDoer(doer),
+		WithCache(c),
+		WithConditionalRequests(),
+		WithRetryBudget(NewRetryBudget(10, 0)),
+	)
+	defer client.Close()
+
+	uids := []string{"618285856", "700000001", "700000002", "700000003", "700000004"}
+
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			profile, err := client.GetProfile(context.Background(), uids[i%len(uids)])
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if profile.PlayerInfo.Nickname != "Kirin" || len(profile.ShowcasedAvatarIDs()) != 2 {
+				t.Errorf("unexpected profile: %+v", profile.PlayerInfo)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Concurrent requests for the same UID are coalesced or served from the cache
+	if n := requests.Load(); n < int32(len(uids)) || n > 100 {
+		t.Errorf("expected between %d and 100 requests, got %d", len(uids), n)
+	}
+}
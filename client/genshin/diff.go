@@ -0,0 +1,225 @@
+package genshin
+
+import "reflect"
+
+// ChangeKind describes how a showcase character differs between two profile fetches.
+type ChangeKind int
+
+const (
+	CharacterAdded    ChangeKind = iota // Character is present only in the new profile
+	CharacterRemoved                    // Character is present only in the old profile
+	CharacterModified                   // Character is present in both profiles but its level, constellation or equipment changed
+)
+
+// String returns a human-readable name of the change kind.
+func (k ChangeKind) String() string {
+	switch k {
+	case CharacterAdded:
+		return "added"
+	case CharacterRemoved:
+		return "removed"
+	case CharacterModified:
+		return "modified"
+	default:
+		return "unknown"
+	}
+}
+
+// CharacterChange describes a single showcase character that differs between two profiles.
+type CharacterChange struct {
+	AvatarID int        // Character ID
+	Kind     ChangeKind // How the character changed
+}
+
+// DiffCharacters compares the AvatarInfoList of two profiles and reports which showcase
+// characters were added, removed or modified.
+//
+// A character is considered modified if its level (PropMap entry 4001), constellation
+// level (number of entries in TalentIDList) or equipment (EquipList) differs between the
+// two profiles. Either profile may be nil, in which case it is treated as having an
+// empty showcase.
+//
+// Added and modified characters are reported in the order they appear in the new profile,
+// followed by removed characters in the order they appear in the old profile.
+//
+// Example:
+//
+//	changes := genshin.DiffCharacters(previous, current)
+//	for _, change := range changes {
+//	    fmt.Println(change.AvatarID, change.Kind)
+//	}
+func DiffCharacters(old, new *Profile) []CharacterChange {
+	var oldList, newList []AvatarInfo
+	if old != nil {
+		oldList = old.AvatarInfoList
+	}
+	if new != nil {
+		newList = new.AvatarInfoList
+	}
+
+	oldByID := make(map[int]*AvatarInfo, len(oldList))
+	for i := range oldList {
+		oldByID[oldList[i].AvatarID] = &oldList[i]
+	}
+
+	var changes []CharacterChange
+	seen := make(map[int]bool, len(newList))
+
+	for i := range newList {
+		avatar := &newList[i]
+		seen[avatar.AvatarID] = true
+
+		prev, ok := oldByID[avatar.AvatarID]
+		if !ok {
+			changes = append(changes, CharacterChange{AvatarID: avatar.AvatarID, Kind: CharacterAdded})
+			continue
+		}
+
+		if characterChanged(prev, avatar) {
+			changes = append(changes, CharacterChange{AvatarID: avatar.AvatarID, Kind: CharacterModified})
+		}
+	}
+
+	for _, avatar := range oldList {
+		if !seen[avatar.AvatarID] {
+			changes = append(changes, CharacterChange{AvatarID: avatar.AvatarID, Kind: CharacterRemoved})
+		}
+	}
+
+	return changes
+}
+
+// characterChanged reports whether the level, constellation level or equipment of
+// a character differs between two snapshots.
+func characterChanged(old, new *AvatarInfo) bool {
+	if old.PropMap["4001"].Val != new.PropMap["4001"].Val {
+		return true
+	}
+
+	if len(old.TalentIDList) != len(new.TalentIDList) {
+		return true
+	}
+
+	return !reflect.DeepEqual(old.EquipList, new.EquipList)
+}
+
+// IntChange holds the old and new values of a numeric field compared by DiffProfiles.
+type IntChange struct {
+	Old int // Value in the old profile
+	New int // Value in the new profile
+}
+
+// Changed reports whether the value differs between the two profiles.
+func (c IntChange) Changed() bool {
+	return c.Old != c.New
+}
+
+// StringChange holds the old and new values of a text field compared by DiffProfiles.
+type StringChange struct {
+	Old string // Value in the old profile
+	New string // Value in the new profile
+}
+
+// Changed reports whether the value differs between the two profiles.
+func (c StringChange) Changed() bool {
+	return c.Old != c.New
+}
+
+// CharacterDiff details the changes of a showcase character present in both profiles
+// compared by DiffProfiles.
+type CharacterDiff struct {
+	AvatarID         int       // Character ID
+	Level            IntChange // Character level
+	Constellation    IntChange // Constellation level
+	WeaponChanged    bool      // Whether the weapon was swapped, leveled or refined
+	ArtifactsChanged bool      // Whether any artifact was swapped or leveled
+}
+
+// ProfileDiff describes the changes between two snapshots of a profile, as returned by
+// DiffProfiles.
+type ProfileDiff struct {
+	Nickname   StringChange // Player nickname
+	Level      IntChange    // Adventure Rank
+	WorldLevel IntChange    // World level
+
+	Added    []int           // IDs of the characters present only in the new showcase
+	Removed  []int           // IDs of the characters present only in the old showcase
+	Modified []CharacterDiff // Characters whose level, constellation or equipment changed
+}
+
+// Empty reports whether the two profiles have no notable difference.
+func (d ProfileDiff) Empty() bool {
+	return !d.Nickname.Changed() && !d.Level.Changed() && !d.WorldLevel.Changed() &&
+		len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// DiffProfiles compares two snapshots of a profile, such as a profile fetched
+// periodically, and reports the notable changes: the nickname, Adventure Rank and world
+// level of the player, and the showcase characters that were added, removed or modified.
+//
+// Characters are compared as in DiffCharacters, and are listed in the same order. For
+// modified characters, the level and constellation changes are detailed, along with
+// whether the weapon or the artifacts changed. Either profile may be nil, in which case
+// it is treated as an empty profile.
+//
+// Example:
+//
+//	diff := genshin.DiffProfiles(previous, current)
+//	for _, change := range diff.Modified {
+//	    if change.Level.Changed() {
+//	        fmt.Printf("%d leveled up from %d to %d\n", change.AvatarID, change.Level.Old, change.Level.New)
+//	    }
+//	}
+func DiffProfiles(old, new *Profile) ProfileDiff {
+	var oldProfile, newProfile Profile
+	if old != nil {
+		oldProfile = *old
+	}
+	if new != nil {
+		newProfile = *new
+	}
+
+	diff := ProfileDiff{
+		Nickname:   StringChange{oldProfile.PlayerInfo.Nickname, newProfile.PlayerInfo.Nickname},
+		Level:      IntChange{oldProfile.PlayerInfo.Level, newProfile.PlayerInfo.Level},
+		WorldLevel: IntChange{oldProfile.PlayerInfo.WorldLevel, newProfile.PlayerInfo.WorldLevel},
+	}
+
+	for _, change := range DiffCharacters(old, new) {
+		switch change.Kind {
+		case CharacterAdded:
+			diff.Added = append(diff.Added, change.AvatarID)
+		case CharacterRemoved:
+			diff.Removed = append(diff.Removed, change.AvatarID)
+		case CharacterModified:
+			prev := findAvatar(oldProfile.AvatarInfoList, change.AvatarID)
+			curr := findAvatar(newProfile.AvatarInfoList, change.AvatarID)
+			diff.Modified = append(diff.Modified, CharacterDiff{
+				AvatarID:         change.AvatarID,
+				Level:            IntChange{prev.Level(), curr.Level()},
+				Constellation:    IntChange{prev.ConstellationLevel(), curr.ConstellationLevel()},
+				WeaponChanged:    !reflect.DeepEqual(weaponOf(prev), weaponOf(curr)),
+				ArtifactsChanged: !reflect.DeepEqual(prev.Artifacts(), curr.Artifacts()),
+			})
+		}
+	}
+
+	return diff
+}
+
+// findAvatar returns the character with the given ID in list. DiffProfiles only looks
+// up characters reported by DiffCharacters, so the character is always present.
+func findAvatar(list []AvatarInfo, avatarID int) *AvatarInfo {
+	for i := range list {
+		if list[i].AvatarID == avatarID {
+			return &list[i]
+		}
+	}
+	return &AvatarInfo{}
+}
+
+// weaponOf returns the weapon equipped by a character, or nil if there is none.
+func weaponOf(a *AvatarInfo) *Equip {
+	weapon, _ := a.EquippedWeapon()
+	return weapon
+}
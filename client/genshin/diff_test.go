@@ -0,0 +1,106 @@
+package genshin
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestDiffProfiles checks that player and character changes between two snapshots are detailed.
+func TestDiffProfiles(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	old, err := DecodeProfile(data)
+	if err != nil {
+		t.Fatalf("failed to decode profile: %v", err)
+	}
+	new, err := DecodeProfile(data)
+	if err != nil {
+		t.Fatalf("failed to decode profile: %v", err)
+	}
+
+	if diff := DiffProfiles(old, new); !diff.Empty() {
+		t.Errorf("expected no difference between identical profiles, got %+v", diff)
+	}
+
+	// Raise the world level, change the level, constellation and weapon of the
+	// character, and add a second one to the showcase
+	new.PlayerInfo.WorldLevel++
+	character := &new.AvatarInfoList[0]
+	character.PropMap["4001"] = Prop{Type: 4001, Ival: "80", Val: "80"}
+	character.TalentIDList = append(character.TalentIDList, 1)
+	for i := range character.EquipList {
+		if weapon := character.EquipList[i].Weapon; weapon != nil {
+			weapon.Level--
+		}
+	}
+	new.AvatarInfoList = append(new.AvatarInfoList, AvatarInfo{AvatarID: 10000046})
+
+	diff := DiffProfiles(old, new)
+	want := ProfileDiff{
+		Nickname:   StringChange{"Kirin", "Kirin"},
+		Level:      IntChange{old.PlayerInfo.Level, old.PlayerInfo.Level},
+		WorldLevel: IntChange{9, 10},
+		Added:      []int{10000046},
+		Modified: []CharacterDiff{{
+			AvatarID:      character.AvatarID,
+			Level:         IntChange{90, 80},
+			Constellation: IntChange{2, 3},
+			WeaponChanged: true,
+		}},
+	}
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("DiffProfiles() = %+v, want %+v", diff, want)
+	}
+
+	if diff := DiffProfiles(nil, old); len(diff.Added) != 1 || diff.Nickname.Old != "" {
+		t.Errorf("expected every character to be added to a nil profile, got %+v", diff)
+	}
+}
+
+// TestDiffCharacters checks that added, removed and modified showcase characters are reported in order.
+func TestDiffCharacters(t *testing.T) {
+	avatar := func(id int, level string, constellations int, weaponLevel int) AvatarInfo {
+		return AvatarInfo{
+			AvatarID:     id,
+			PropMap:      map[string]Prop{"4001": {Type: 4001, Ival: level, Val: level}},
+			TalentIDList: make([]int, constellations),
+			EquipList:    []Equip{{ItemID: 11509, Weapon: &Weapon{Level: weaponLevel}}},
+		}
+	}
+	profile := func(avatars ...AvatarInfo) *Profile {
+		return &Profile{AvatarInfoList: avatars}
+	}
+
+	tests := []struct {
+		name string
+		old  *Profile
+		new  *Profile
+		want []CharacterChange
+	}{
+		{"identical", profile(avatar(1, "90", 0, 90)), profile(avatar(1, "90", 0, 90)), nil},
+		{"both nil", nil, nil, nil},
+		{"added", profile(avatar(1, "90", 0, 90)), profile(avatar(1, "90", 0, 90), avatar(2, "80", 0, 90)), []CharacterChange{{2, CharacterAdded}}},
+		{"removed", profile(avatar(1, "90", 0, 90), avatar(2, "80", 0, 90)), profile(avatar(2, "80", 0, 90)), []CharacterChange{{1, CharacterRemoved}}},
+		{"level", profile(avatar(1, "80", 0, 90)), profile(avatar(1, "90", 0, 90)), []CharacterChange{{1, CharacterModified}}},
+		{"constellation", profile(avatar(1, "90", 1, 90)), profile(avatar(1, "90", 2, 90)), []CharacterChange{{1, CharacterModified}}},
+		{"equipment", profile(avatar(1, "90", 0, 80)), profile(avatar(1, "90", 0, 90)), []CharacterChange{{1, CharacterModified}}},
+		{"from nil", nil, profile(avatar(1, "90", 0, 90)), []CharacterChange{{1, CharacterAdded}}},
+		{"to nil", profile(avatar(1, "90", 0, 90)), nil, []CharacterChange{{1, CharacterRemoved}}},
+		{
+			"mixed",
+			profile(avatar(1, "90", 0, 90), avatar(2, "80", 0, 90), avatar(3, "70", 0, 90)),
+			profile(avatar(4, "90", 0, 90), avatar(3, "80", 0, 90), avatar(2, "80", 0, 90)),
+			[]CharacterChange{{4, CharacterAdded}, {3, CharacterModified}, {1, CharacterRemoved}},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := DiffCharacters(tt.old, tt.new); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: DiffCharacters() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,11 @@ package genshin
 
 import "github.com/kirinyoku/enkanetwork-go/internal/core/errors"
 
+// APIError carries details of a failed request beyond the error it wraps, such as the
+// delay requested by the API's Retry-After header when ErrRateLimited is returned. Use
+// errors.As to read it.
+type APIError = errors.APIError
+
 var (
 	ErrInvalidUIDFormat   = errors.ErrInvalidUIDFormat
 	ErrPlayerNotFound     = errors.ErrPlayerNotFound
@@ -9,4 +14,30 @@ var (
 	ErrServerError        = errors.ErrServerError
 	ErrServiceUnavailable = errors.ErrServiceUnavailable
 	ErrRateLimited        = errors.ErrRateLimited
+	ErrNoOwner            = errors.ErrNoOwner
+	ErrTruncatedResponse  = errors.ErrTruncatedResponse
+	ErrResponseTooLarge   = errors.ErrResponseTooLarge
+	ErrNoContent          = errors.ErrNoContent
+	ErrUnexpectedStatus   = errors.ErrUnexpectedStatus
+
+	ErrInvalidUsername           = errors.ErrInvalidUsername
+	ErrInvalidHoyoHash           = errors.ErrInvalidHoyoHash
+	ErrHoyoAccountBuildsNotFound = errors.ErrHoyoAccountBuildsNotFound
+
+	ErrUserAgentRequired = errors.ErrUserAgentRequired
+	ErrInvalidUserAgent  = errors.ErrInvalidUserAgent
+	ErrInvalidLanguage   = errors.ErrInvalidLanguage
+
+	// ErrStaleData is returned along with a stale cached value when a request fails with
+	// a transient error and the client was created with WithServeStaleOnError.
+	ErrStaleData = errors.ErrStaleData
+
+	// ErrCacheTypeMismatch is reported to the handler set with WithCacheErrorHandler
+	// when a cached value does not have the expected type and is ignored.
+	ErrCacheTypeMismatch = errors.ErrCacheTypeMismatch
+
+	// ErrProfileNotCachedYet is returned instead of ErrPlayerNotFound when the API
+	// indicates that the account exists but its data has not been fetched yet.
+	// It wraps ErrPlayerNotFound, so errors.Is(err, ErrPlayerNotFound) is true for it.
+	ErrProfileNotCachedYet = errors.ErrProfileNotCachedYet
 )
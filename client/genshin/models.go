@@ -1,6 +1,11 @@
 package genshin
 
-import "github.com/kirinyoku/enkanetwork-go/models"
+import (
+	"encoding/json"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/jsonutil"
+	"github.com/kirinyoku/enkanetwork-go/models"
+)
 
 // ------------------------------- IMPORTANT --------------------------------------
 // For detailed information on properties, refer to the EnkaNetwork API — Genshin
@@ -14,7 +19,8 @@ type Profile struct {
 	// PlayerInfo contains basic information about the game account from the player's showcase
 	PlayerInfo models.PlayerInfo `json:"playerInfo"`
 	// AvatarInfoList contains detailed information for each character in the showcase.
-	// If missing, the showcase is either hidden by the player or contains no characters.
+	// If missing, the showcase is either hidden by the player or contains no characters;
+	// ShowcaseHidden tells the two cases apart.
 	// The GetPlayerInfo method always returns AvatarInfoList as an empty slice.
 	AvatarInfoList []AvatarInfo `json:"avatarInfoList,omitempty"`
 	// Owner is the Enka profile associated with the provided UID.
@@ -33,6 +39,34 @@ type Profile struct {
 	UID string `json:"uid,omitempty"`
 	// Region is the server region of the player (e.g., "NA", "EU", "Asia", "TW, HK, MO").
 	Region string `json:"region,omitempty"`
+	// Extra contains the top-level fields of the API response that are not mapped to
+	// any field of this struct. It allows reading newly added properties before the
+	// library is updated to support them
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Profile. It decodes
+// the known fields of a profile as usual and stores the other top-level fields in Extra.
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	type profile Profile
+
+	var aux profile
+	extra, err := jsonutil.UnmarshalExtra(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	*p = Profile(aux)
+	p.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Profile. The fields in Extra are
+// encoded along with the others, so they survive a round trip.
+func (p Profile) MarshalJSON() ([]byte, error) {
+	type profile Profile
+	return jsonutil.MarshalExtra(profile(p), p.Extra)
 }
 
 // AvatarInfo contains detailed information for characters in the showcase.
@@ -49,13 +83,16 @@ type AvatarInfo struct {
 	FetterInfo              *FetterInfo        `json:"fetterInfo,omitempty"`              // Character friendship level information
 }
 
+// hoyoType is the hoyo_type value of builds and hoyos belonging to Genshin Impact accounts.
+const hoyoType = 0
+
 // Build contains information about a specific character build in Genshin Impact.
 type Build struct {
-	ID         int         `json:"id,omitempty"`          // Unique identifier for the build
-	Name       string      `json:"name,omitempty"`        // Name of the build
-	AvatarID   string      `json:"avatar_id,omitempty"`   // ID of the character
-	AvatarData *AvatarInfo `json:"avatar_data,omitempty"` // Character data (*genshin.AvatarInfo)
-	Order      int         `json:"order,omitempty"`       // Order of the saved build on Enka
+	ID         int         `json:"id,omitempty"`           // Unique identifier for the build
+	Name       string      `json:"name,omitempty"`         // Name of the build
+	AvatarID   string      `json:"avatar_id,omitempty"`    // ID of the character
+	AvatarData *AvatarInfo `json:"avatar_data,omitempty"`  // Character data (*genshin.AvatarInfo)
+	Order      int         `json:"order,string,omitempty"` // Order of the saved build on Enka, sent as a string by the API
 	// If a build has a live: true field, it indicates it is not a saved build but one
 	// retrieved from the game’s showcase when the "refresh" button is clicked. During
 	// an update, all old live builds are deleted, and new ones are created. Updates
@@ -141,11 +178,21 @@ type Prop struct {
 	Val  string `json:"val,omitempty"`  // Value of the property
 }
 
-// Settings represents build-specific configuration options.
-type Settings struct {
-	AdaptiveColor *bool    `json:"adaptiveColor,omitempty"` // Whether adaptive color is enabled
-	ArtSource     *string  `json:"artSource,omitempty"`     // Source of the image
-	Caption       *string  `json:"caption,omitempty"`       // Caption of the build
-	HonkardWidth  *float64 `json:"honkardWidth,omitempty"`  // Width of the image
-	Transform     *string  `json:"transform,omitempty"`     // Transformation applied to the image
-}
+// Settings represents the display settings of a build. It is the same type in all the
+// game packages.
+type Settings = models.BuildSettings
+
+// StatEntry is a single stat of a character (see AvatarStats). It is the same type in all
+// the game packages.
+type StatEntry = models.StatEntry
+
+// AbyssProgress is the Spiral Abyss progress of a player (see PlayerInfo.SpiralAbyss).
+type AbyssProgress = models.AbyssProgress
+
+// TheaterProgress is the Imaginarium Theater progress of a player (see
+// PlayerInfo.TheaterProgress).
+type TheaterProgress = models.TheaterProgress
+
+// StygianProgress is the Stygian Onslaught progress of a player (see
+// PlayerInfo.StygianProgress).
+type StygianProgress = models.StygianProgress
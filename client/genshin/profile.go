@@ -0,0 +1,51 @@
+package genshin
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeProfile decodes a profile from the JSON body of an API response. It performs
+// the same decoding as GetProfile, including filling Profile.Extra, and is meant for
+// applications that obtain the response themselves, e.g. through their own proxy, and
+// only need the models of this package.
+//
+// Example:
+//
+//	profile, err := genshin.DecodeProfile(body)
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+func DecodeProfile(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to decode profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// ShowcasedAvatarIDs returns the IDs of the characters in the player's showcase, in
+// showcase order. The IDs are taken from PlayerInfo.ShowAvatarInfoList, so they are
+// available even when AvatarInfoList is not (e.g., for profiles returned by
+// GetPlayerInfo or with hidden character details).
+func (p *Profile) ShowcasedAvatarIDs() []int {
+	ids := make([]int, 0, len(p.PlayerInfo.ShowAvatarInfoList))
+	for _, avatar := range p.PlayerInfo.ShowAvatarInfoList {
+		ids = append(ids, avatar.AvatarID)
+	}
+	return ids
+}
+
+// ShowcaseHidden reports whether the player has hidden the details of the characters in
+// their showcase. The API does not report this explicitly: a player who disabled
+// "Show Character Details" still lists the characters in PlayerInfo.ShowAvatarInfoList,
+// but AvatarInfoList is absent. A showcase with no characters at all is not considered
+// hidden.
+//
+// Profiles returned by GetPlayerInfo never include AvatarInfoList, so ShowcaseHidden is
+// only meaningful for profiles returned by GetProfile.
+func (p *Profile) ShowcaseHidden() bool {
+	return len(p.PlayerInfo.ShowAvatarInfoList) > 0 && len(p.AvatarInfoList) == 0
+}
@@ -0,0 +1,114 @@
+package genshin
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+	"github.com/kirinyoku/enkanetwork-go/models"
+)
+
+// TestDecodeProfile checks that a captured API response decodes into the expected values.
+func TestDecodeProfile(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	profile, err := DecodeProfile(data)
+	if err != nil {
+		t.Fatalf("failed to decode profile: %v", err)
+	}
+
+	character := profile.AvatarInfoList[0]
+	artifact, _ := character.EquipList[0].reliquaryFlat()
+	weapon, hasWeapon := character.EquippedWeapon()
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"nickname", profile.PlayerInfo.Nickname, "Kirin"},
+		{"world level", profile.PlayerInfo.WorldLevel, 9},
+		{"showcased avatars", profile.ShowcasedAvatarIDs(), []int{10000089, 10000046}},
+		{"showcase hidden", profile.ShowcaseHidden(), false},
+		{"character level", character.Level(), 90},
+		{"ascension phase", character.AscensionPhase(), 6},
+		{"experience", character.Experience(), 0},
+		{"constellation level", character.ConstellationLevel(), 2},
+		{"artifact main stat", artifact.ReliquaryMainstat.MainPropID, "FIGHT_PROP_CRITICAL"},
+		{"artifact set", artifact.SetID, 15034},
+		{"weapon refinement", character.EquipList[1].Weapon.RefinementLevel(), 1},
+		{"equipped weapon", hasWeapon && weapon == &character.EquipList[1], true},
+		{"equipped weapon base attack", weapon.BaseAttack(), 542.0},
+		{"equipped weapon refinement", weapon.RefinementLevel(), 1},
+		{"artifacts", character.Artifacts(), []Equip{character.EquipList[0]}},
+		{"stats", character.AvatarStats(), []StatEntry{{ID: 20, Name: "FIGHT_PROP_CRITICAL", Value: 0.735}, {ID: 22, Name: "FIGHT_PROP_CRITICAL_HURT", Value: 2.3044}, {ID: 2000, Name: "FIGHT_PROP_MAX_HP", Value: 36853.27}}},
+		{"ttl", profile.TTL, 60},
+		{"extra", len(profile.Extra), 0},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	encoded, err := json.Marshal(profile)
+	if err != nil {
+		t.Fatalf("failed to encode profile: %v", err)
+	}
+	if missing := core.DiffJSONKeys(data, encoded); len(missing) > 0 {
+		t.Errorf("fixture contains fields missing from the structs: %v", missing)
+	}
+	if got, want := core.NormalizeJSON(encoded), core.NormalizeJSON(data); !bytes.Equal(got, want) {
+		t.Errorf("re-encoded profile differs from the fixture:\n got %s\nwant %s", got, want)
+	}
+}
+
+// TestShowcaseHidden checks that hidden character details are told apart from an empty showcase.
+func TestShowcaseHidden(t *testing.T) {
+	listed := models.PlayerInfo{ShowAvatarInfoList: []models.ShowAvatarInfo{{AvatarID: 10000089}}}
+
+	tests := []struct {
+		name    string
+		profile Profile
+		want    bool
+	}{
+		{"visible", Profile{PlayerInfo: listed, AvatarInfoList: []AvatarInfo{{AvatarID: 10000089}}}, false},
+		{"hidden", Profile{PlayerInfo: listed}, true},
+		{"empty", Profile{}, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.profile.ShowcaseHidden(); got != tt.want {
+			t.Errorf("%s: ShowcaseHidden() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestProfileIconURL checks that profile pictures, namecards and showcases resolve to EnkaNetwork URLs.
+func TestProfileIconURL(t *testing.T) {
+	if url, ok := ProfileIconURL(10000089); !ok || url != "https://enka.network/ui/UI_AvatarIcon_Furina.png" {
+		t.Errorf("ProfileIconURL(10000089) = %q, %v", url, ok)
+	}
+	if url, ok := ProfileIconURL(1); ok || url != "" {
+		t.Errorf("ProfileIconURL(1) = %q, %v, want \"\", false", url, ok)
+	}
+	if url, ok := NamecardURL(210001); !ok || url != "https://enka.network/ui/UI_NameCardPic_0_P.png" {
+		t.Errorf("NamecardURL(210001) = %q, %v", url, ok)
+	}
+	if url := ShowcaseURL("618285856"); url != "https://enka.network/u/618285856/" {
+		t.Errorf("ShowcaseURL(\"618285856\") = %q", url)
+	}
+	if url := ShowcaseURL("61828585"); url != "" {
+		t.Errorf("ShowcaseURL(\"61828585\") = %q, want \"\"", url)
+	}
+	if url := IconURL(""); url != "" {
+		t.Errorf("IconURL(\"\") = %q, want \"\"", url)
+	}
+}
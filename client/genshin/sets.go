@@ -0,0 +1,91 @@
+package genshin
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// ArtifactSet describes an artifact set equipped on a character with enough pieces
+// to activate at least its 2-piece bonus.
+type ArtifactSet struct {
+	SetID              int    // Artifact set ID
+	SetNameTextMapHash string // Hash for artifact set name (see localizations: https://github.com/EnkaNetwork/API-docs/blob/master/docs/gi/api.md#localizations)
+	Count              int    // Number of equipped artifacts belonging to the set
+}
+
+// TwoPiece reports whether the 2-piece bonus of the set is active.
+func (s ArtifactSet) TwoPiece() bool {
+	return s.Count >= 2
+}
+
+// FourPiece reports whether the 4-piece bonus of the set is active.
+func (s ArtifactSet) FourPiece() bool {
+	return s.Count >= 4
+}
+
+// ArtifactSetCounts groups the artifacts equipped on the character by their set ID
+// and returns the number of artifacts of each set. The weapon, which is also part of
+// EquipList, is not counted.
+func (a *AvatarInfo) ArtifactSetCounts() map[int]int {
+	counts := make(map[int]int)
+	for _, equip := range a.EquipList {
+		if flat, ok := equip.reliquaryFlat(); ok {
+			counts[flat.SetID]++
+		}
+	}
+	return counts
+}
+
+// ActiveArtifactSets returns the artifact sets with at least 2 equipped pieces, i.e.
+// the sets whose 2-piece (and possibly 4-piece) bonus is active. The result is sorted
+// by set ID.
+//
+// Example:
+//
+//	for _, set := range character.ActiveArtifactSets() {
+//	    fmt.Println(set.SetID, set.TwoPiece(), set.FourPiece())
+//	}
+func (a *AvatarInfo) ActiveArtifactSets() []ArtifactSet {
+	names := make(map[int]string)
+	for _, equip := range a.EquipList {
+		if flat, ok := equip.reliquaryFlat(); ok && flat.SetNameTextMapHash != "" {
+			names[flat.SetID] = flat.SetNameTextMapHash
+		}
+	}
+
+	var sets []ArtifactSet
+	for setID, count := range a.ArtifactSetCounts() {
+		if count >= 2 {
+			sets = append(sets, ArtifactSet{SetID: setID, SetNameTextMapHash: names[setID], Count: count})
+		}
+	}
+
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].SetID < sets[j].SetID
+	})
+
+	return sets
+}
+
+// reliquaryFlat decodes the flat data of an artifact. It returns false if the
+// equipment is not an artifact or its flat data cannot be decoded.
+//
+// Flat is decoded into an untyped value by encoding/json, so it is re-encoded and
+// decoded into FlatReliquary here.
+func (e Equip) reliquaryFlat() (*FlatReliquary, bool) {
+	if e.Reliquary == nil || e.Flat == nil {
+		return nil, false
+	}
+
+	data, err := json.Marshal(e.Flat)
+	if err != nil {
+		return nil, false
+	}
+
+	var flat FlatReliquary
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return nil, false
+	}
+
+	return &flat, true
+}
@@ -0,0 +1,92 @@
+package genshin
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestActiveArtifactSets checks the set counts and the 2-piece and 4-piece thresholds,
+// ignoring the weapon.
+func TestActiveArtifactSets(t *testing.T) {
+	artifact := func(setID int, hash string) Equip {
+		return Equip{
+			Reliquary: &Reliquary{Level: 21},
+			Flat:      map[string]any{"setId": setID, "setNameTextMapHash": hash, "itemType": "ITEM_RELIQUARY"},
+		}
+	}
+	weapon := Equip{ItemID: 11509, Weapon: &Weapon{Level: 90}, Flat: map[string]any{"itemType": "ITEM_WEAPON"}}
+
+	tests := []struct {
+		name       string
+		equipList  []Equip
+		wantCounts map[int]int
+		want       []ArtifactSet
+	}{
+		{"no artifacts", []Equip{weapon}, map[int]int{}, nil},
+		{
+			"one piece of each",
+			[]Equip{artifact(15031, "a"), artifact(15034, "b"), weapon},
+			map[int]int{15031: 1, 15034: 1},
+			nil,
+		},
+		{
+			"2+2",
+			[]Equip{artifact(15034, "b"), artifact(15031, "a"), artifact(15034, "b"), artifact(15031, "a"), artifact(15020, "c"), weapon},
+			map[int]int{15020: 1, 15031: 2, 15034: 2},
+			[]ArtifactSet{{15031, "a", 2}, {15034, "b", 2}},
+		},
+		{
+			"3 pieces",
+			[]Equip{artifact(15034, "b"), artifact(15034, "b"), artifact(15034, "b"), weapon},
+			map[int]int{15034: 3},
+			[]ArtifactSet{{15034, "b", 3}},
+		},
+		{
+			"4 pieces",
+			[]Equip{artifact(15034, "b"), artifact(15034, "b"), artifact(15034, "b"), artifact(15034, "b"), artifact(15031, "a"), weapon},
+			map[int]int{15031: 1, 15034: 4},
+			[]ArtifactSet{{15034, "b", 4}},
+		},
+		{
+			"5 pieces",
+			[]Equip{artifact(15034, "b"), artifact(15034, "b"), artifact(15034, "b"), artifact(15034, "b"), artifact(15034, "b")},
+			map[int]int{15034: 5},
+			[]ArtifactSet{{15034, "b", 5}},
+		},
+	}
+
+	for _, tt := range tests {
+		character := &AvatarInfo{EquipList: tt.equipList}
+		if got := character.ArtifactSetCounts(); !reflect.DeepEqual(got, tt.wantCounts) {
+			t.Errorf("%s: ArtifactSetCounts() = %v, want %v", tt.name, got, tt.wantCounts)
+		}
+		if got := character.ActiveArtifactSets(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: ActiveArtifactSets() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestArtifactSetBonuses checks the thresholds of the 2-piece and 4-piece bonuses.
+func TestArtifactSetBonuses(t *testing.T) {
+	tests := []struct {
+		count     int
+		twoPiece  bool
+		fourPiece bool
+	}{
+		{1, false, false},
+		{2, true, false},
+		{3, true, false},
+		{4, true, true},
+		{5, true, true},
+	}
+
+	for _, tt := range tests {
+		set := ArtifactSet{SetID: 15034, Count: tt.count}
+		if got := set.TwoPiece(); got != tt.twoPiece {
+			t.Errorf("TwoPiece() with %d pieces = %v, want %v", tt.count, got, tt.twoPiece)
+		}
+		if got := set.FourPiece(); got != tt.fourPiece {
+			t.Errorf("FourPiece() with %d pieces = %v, want %v", tt.count, got, tt.fourPiece)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package genshin
+
+import (
+	"sort"
+	"strconv"
+)
+
+// fightPropNames maps the IDs of FightPropMap to the names of the properties (see
+// https://github.com/EnkaNetwork/API-docs/blob/master/docs/gi/api.md#fightprop).
+var fightPropNames = map[int]string{
+	1:    "FIGHT_PROP_BASE_HP",
+	2:    "FIGHT_PROP_HP",
+	3:    "FIGHT_PROP_HP_PERCENT",
+	4:    "FIGHT_PROP_BASE_ATTACK",
+	5:    "FIGHT_PROP_ATTACK",
+	6:    "FIGHT_PROP_ATTACK_PERCENT",
+	7:    "FIGHT_PROP_BASE_DEFENSE",
+	8:    "FIGHT_PROP_DEFENSE",
+	9:    "FIGHT_PROP_DEFENSE_PERCENT",
+	10:   "FIGHT_PROP_BASE_SPEED",
+	11:   "FIGHT_PROP_SPEED_PERCENT",
+	20:   "FIGHT_PROP_CRITICAL",
+	22:   "FIGHT_PROP_CRITICAL_HURT",
+	23:   "FIGHT_PROP_CHARGE_EFFICIENCY",
+	26:   "FIGHT_PROP_HEAL_ADD",
+	27:   "FIGHT_PROP_HEALED_ADD",
+	28:   "FIGHT_PROP_ELEMENT_MASTERY",
+	29:   "FIGHT_PROP_PHYSICAL_SUB_HURT",
+	30:   "FIGHT_PROP_PHYSICAL_ADD_HURT",
+	40:   "FIGHT_PROP_FIRE_ADD_HURT",
+	41:   "FIGHT_PROP_ELEC_ADD_HURT",
+	42:   "FIGHT_PROP_WATER_ADD_HURT",
+	43:   "FIGHT_PROP_GRASS_ADD_HURT",
+	44:   "FIGHT_PROP_WIND_ADD_HURT",
+	45:   "FIGHT_PROP_ROCK_ADD_HURT",
+	46:   "FIGHT_PROP_ICE_ADD_HURT",
+	50:   "FIGHT_PROP_FIRE_SUB_HURT",
+	51:   "FIGHT_PROP_ELEC_SUB_HURT",
+	52:   "FIGHT_PROP_WATER_SUB_HURT",
+	53:   "FIGHT_PROP_GRASS_SUB_HURT",
+	54:   "FIGHT_PROP_WIND_SUB_HURT",
+	55:   "FIGHT_PROP_ROCK_SUB_HURT",
+	56:   "FIGHT_PROP_ICE_SUB_HURT",
+	70:   "FIGHT_PROP_MAX_FIRE_ENERGY",
+	71:   "FIGHT_PROP_MAX_ELEC_ENERGY",
+	72:   "FIGHT_PROP_MAX_WATER_ENERGY",
+	73:   "FIGHT_PROP_MAX_GRASS_ENERGY",
+	74:   "FIGHT_PROP_MAX_WIND_ENERGY",
+	75:   "FIGHT_PROP_MAX_ICE_ENERGY",
+	76:   "FIGHT_PROP_MAX_ROCK_ENERGY",
+	80:   "FIGHT_PROP_SKILL_CD_MINUS_RATIO",
+	81:   "FIGHT_PROP_SHIELD_COST_MINUS_RATIO",
+	1000: "FIGHT_PROP_CUR_FIRE_ENERGY",
+	1001: "FIGHT_PROP_CUR_ELEC_ENERGY",
+	1002: "FIGHT_PROP_CUR_WATER_ENERGY",
+	1003: "FIGHT_PROP_CUR_GRASS_ENERGY",
+	1004: "FIGHT_PROP_CUR_WIND_ENERGY",
+	1005: "FIGHT_PROP_CUR_ICE_ENERGY",
+	1006: "FIGHT_PROP_CUR_ROCK_ENERGY",
+	1010: "FIGHT_PROP_CUR_HP",
+	2000: "FIGHT_PROP_MAX_HP",
+	2001: "FIGHT_PROP_CUR_ATTACK",
+	2002: "FIGHT_PROP_CUR_DEFENSE",
+	2003: "FIGHT_PROP_CUR_SPEED",
+}
+
+// AvatarStats returns every combat property of the character listed in FightPropMap,
+// sorted by ID. The Name of an entry is the FIGHT_PROP name of its ID, or empty if the
+// ID is not known to this version of the library. Percentages are fractions, as in the
+// API (e.g., 0.735 for 73.5% CRIT Rate).
+func (a *AvatarInfo) AvatarStats() []StatEntry {
+	stats := make([]StatEntry, 0, len(a.FightPropMap))
+	for key, value := range a.FightPropMap {
+		id, err := strconv.Atoi(key)
+		if err != nil {
+			continue
+		}
+		stats = append(stats, StatEntry{ID: id, Name: fightPropNames[id], Value: value})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].ID < stats[j].ID
+	})
+
+	return stats
+}
@@ -0,0 +1,85 @@
+package genshin
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// TextMapResolver resolves the text map hashes found in profiles, such as the
+// NameTextMapHash of FlatReliquary, into display strings in a given language (e.g.,
+// "en" or "ja"). It reports false if the hash or language is unknown.
+//
+// TextMap, loaded with LoadTextMap, is the implementation provided by the library, but
+// any source of localized strings can be used.
+type TextMapResolver interface {
+	Resolve(hash string, lang string) (string, bool)
+}
+
+// TextMap is a TextMapResolver backed by the localization file published by EnkaNetwork
+// (https://github.com/EnkaNetwork/API-docs/blob/master/store/loc.json). It maps language
+// codes to tables of hashes and their text.
+type TextMap map[string]map[string]string
+
+// Resolve returns the text of hash in lang, or false if either is not in the text map.
+func (m TextMap) Resolve(hash string, lang string) (string, bool) {
+	text, ok := m[lang][hash]
+	return text, ok
+}
+
+// LoadTextMap reads a text map in the format of the localization file published by
+// EnkaNetwork, where every language code maps to an object of hashes and their text:
+//
+//	{"en": {"1212345779": "Gladiator's Finale"}, "ja": {"1212345779": "剣闘士のフィナーレ"}}
+//
+// The file is not shipped with the library, since it is large and changes with every
+// game version; download it from the EnkaNetwork API-docs repository.
+//
+// Example:
+//
+//	f, err := os.Open("loc.json")
+//	if err != nil {
+//	    return err
+//	}
+//	defer f.Close()
+//
+//	textMap, err := genshin.LoadTextMap(f)
+//	if err != nil {
+//	    return err
+//	}
+//	fmt.Println(artifact.Name(textMap, "en"))
+func LoadTextMap(r io.Reader) (TextMap, error) {
+	var m TextMap
+	if err := json.NewDecoder(r).Decode(&m); err != nil {
+		return nil, fmt.Errorf("decode text map: %w", err)
+	}
+	return m, nil
+}
+
+// Name returns the name of the artifact in lang, resolved from NameTextMapHash. It
+// returns an empty string if resolver is nil or cannot resolve the hash.
+func (f *FlatReliquary) Name(resolver TextMapResolver, lang string) string {
+	return resolveText(resolver, f.NameTextMapHash, lang)
+}
+
+// SetName returns the name of the artifact's set in lang, resolved from
+// SetNameTextMapHash. It returns an empty string if resolver is nil or cannot resolve
+// the hash.
+func (f *FlatReliquary) SetName(resolver TextMapResolver, lang string) string {
+	return resolveText(resolver, f.SetNameTextMapHash, lang)
+}
+
+// Name returns the name of the weapon in lang, resolved from NameTextMapHash. It returns
+// an empty string if resolver is nil or cannot resolve the hash.
+func (f *FlatWeapon) Name(resolver TextMapResolver, lang string) string {
+	return resolveText(resolver, f.NameTextMapHash, lang)
+}
+
+// resolveText resolves hash with resolver, returning an empty string if it cannot.
+func resolveText(resolver TextMapResolver, hash string, lang string) string {
+	if resolver == nil || hash == "" {
+		return ""
+	}
+	text, _ := resolver.Resolve(hash, lang)
+	return text
+}
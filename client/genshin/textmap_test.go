@@ -0,0 +1,39 @@
+package genshin
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextMap(t *testing.T) {
+	textMap, err := LoadTextMap(strings.NewReader(`{"en": {"1": "Gladiator's Finale", "2": "Wolf's Gravestone"}, "ja": {"1": "剣闘士のフィナーレ"}}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	artifact := &FlatReliquary{NameTextMapHash: "3", SetNameTextMapHash: "1"}
+	weapon := &FlatWeapon{NameTextMapHash: "2"}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"set name en", artifact.SetName(textMap, "en"), "Gladiator's Finale"},
+		{"set name ja", artifact.SetName(textMap, "ja"), "剣闘士のフィナーレ"},
+		{"unknown hash", artifact.Name(textMap, "en"), ""},
+		{"unknown language", weapon.Name(textMap, "fr"), ""},
+		{"nil resolver", weapon.Name(nil, "en"), ""},
+		{"weapon name", weapon.Name(textMap, "en"), "Wolf's Gravestone"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if _, err := LoadTextMap(strings.NewReader(`[]`)); err == nil {
+		t.Error("expected an error for an invalid text map")
+	}
+}
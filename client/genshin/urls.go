@@ -0,0 +1,13 @@
+package genshin
+
+import "github.com/kirinyoku/enkanetwork-go/internal/core"
+
+// ShowcaseURL returns the URL of the EnkaNetwork page showing the character showcase of
+// the player uid (e.g., "https://enka.network/u/618285856/"). It returns an empty string
+// if uid is not a 9-digit number.
+func ShowcaseURL(uid string) string {
+	if !core.IsValidUID(uid) {
+		return ""
+	}
+	return core.WebURL + "/u/" + uid + "/"
+}
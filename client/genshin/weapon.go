@@ -0,0 +1,76 @@
+package genshin
+
+import "encoding/json"
+
+// StatBaseAttack is the AppendPropID of the base ATK stat of a weapon.
+const StatBaseAttack = "FIGHT_PROP_BASE_ATTACK"
+
+// EquippedWeapon returns the weapon equipped by the character, which is the entry of
+// EquipList with a non-nil Weapon. It returns nil and false if the list has no weapon.
+//
+// The returned pointer refers to the element of EquipList, not to a copy.
+func (a *AvatarInfo) EquippedWeapon() (*Equip, bool) {
+	for i := range a.EquipList {
+		if a.EquipList[i].Weapon != nil {
+			return &a.EquipList[i], true
+		}
+	}
+	return nil, false
+}
+
+// Artifacts returns the artifacts equipped by the character, which are the entries of
+// EquipList without a Weapon, in the order they are listed. It returns nil if the
+// character has no artifacts equipped.
+func (a *AvatarInfo) Artifacts() []Equip {
+	var artifacts []Equip
+	for _, equip := range a.EquipList {
+		if equip.Weapon == nil {
+			artifacts = append(artifacts, equip)
+		}
+	}
+	return artifacts
+}
+
+// BaseAttack returns the base ATK of the weapon at its current level and ascension. It
+// returns 0 if the equipment is not a weapon or its flat data has no base ATK.
+func (e *Equip) BaseAttack() float64 {
+	flat, ok := e.weaponFlat()
+	if !ok {
+		return 0
+	}
+	for _, stat := range flat.WeaponStats {
+		if stat.AppendPropID == StatBaseAttack {
+			return stat.StatValue
+		}
+	}
+	return 0
+}
+
+// RefinementLevel returns the refinement level (1-5) of the weapon (see
+// Weapon.RefinementLevel). It returns 0 if the equipment is not a weapon.
+func (e *Equip) RefinementLevel() int {
+	if e.Weapon == nil {
+		return 0
+	}
+	return e.Weapon.RefinementLevel()
+}
+
+// weaponFlat decodes the flat data of a weapon. It returns false if the equipment is
+// not a weapon or its flat data cannot be decoded. See reliquaryFlat.
+func (e Equip) weaponFlat() (*FlatWeapon, bool) {
+	if e.Weapon == nil || e.Flat == nil {
+		return nil, false
+	}
+
+	data, err := json.Marshal(e.Flat)
+	if err != nil {
+		return nil, false
+	}
+
+	var flat FlatWeapon
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return nil, false
+	}
+
+	return &flat, true
+}
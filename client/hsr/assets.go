@@ -0,0 +1,93 @@
+package hsr
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// assetsURL is the root URL of the Honkai: Star Rail images hosted by EnkaNetwork. An
+// image is available at assetsURL/<icon path>, where the icon path is the one listed in
+// the EnkaNetwork store data (e.g., "SpriteOutput/AvatarRoundIcon/1309.png").
+const assetsURL = "https://enka.network/ui/hsr"
+
+// headIconsJSON maps profile icon IDs, as found in DetailInfo.HeadIcon, to the paths of
+// their images.
+//
+// To add new icons, take the Icon of each entry of
+// https://github.com/EnkaNetwork/API-docs/blob/master/store/hsr/pfps.json.
+//
+//go:embed assets/head_icons.json
+var headIconsJSON []byte
+
+// personalCardsJSON maps personal card IDs, as found in DetailInfo.PersonalCardID, to
+// the paths of their images.
+//
+// To add new cards, take the icon path of each personal card item from the EnkaNetwork
+// store data.
+//
+//go:embed assets/personal_cards.json
+var personalCardsJSON []byte
+
+// headIcons returns the decoded headIconsJSON. It is decoded on first use.
+var headIcons = sync.OnceValue(func() map[string]string {
+	return decodeAssetTable("head_icons.json", headIconsJSON)
+})
+
+// personalCards returns the decoded personalCardsJSON. It is decoded on first use.
+var personalCards = sync.OnceValue(func() map[string]string {
+	return decodeAssetTable("personal_cards.json", personalCardsJSON)
+})
+
+// decodeAssetTable decodes an embedded table mapping IDs to icon paths. The tables are
+// part of the library, so an invalid table is a programming error and panics.
+func decodeAssetTable(name string, data []byte) map[string]string {
+	var table map[string]string
+	if err := json.Unmarshal(data, &table); err != nil {
+		panic("hsr: invalid assets/" + name + ": " + err.Error())
+	}
+	return table
+}
+
+// IconURL returns the URL of the image with the given icon path on EnkaNetwork, such as
+// "SpriteOutput/AvatarRoundIcon/1309.png". A leading slash is ignored. It returns an
+// empty string if iconPath is empty.
+func IconURL(iconPath string) string {
+	iconPath = strings.TrimPrefix(iconPath, "/")
+	if iconPath == "" {
+		return ""
+	}
+	return assetsURL + "/" + iconPath
+}
+
+// HeadIconURL returns the URL of the profile icon with the given ID, as found in
+// DetailInfo.HeadIcon. It returns an empty string and false if the icon is not known to
+// this version of the library, e.g. because it was added to the game after the release.
+//
+// Example:
+//
+//	if url, ok := hsr.HeadIconURL(profile.DetailInfo.HeadIcon); ok {
+//	    fmt.Println("Profile icon:", url)
+//	}
+func HeadIconURL(id int) (string, bool) {
+	return assetURL(headIcons(), id)
+}
+
+// PersonalCardURL returns the URL of the image of the personal card with the given ID,
+// as found in DetailInfo.PersonalCardID. It returns an empty string and false if the
+// card is not known to this version of the library.
+func PersonalCardURL(id int) (string, bool) {
+	return assetURL(personalCards(), id)
+}
+
+// assetURL returns the URL of the image listed for id in table, or an empty string and
+// false if the table has no entry for id.
+func assetURL(table map[string]string, id int) (string, bool) {
+	path, ok := table[strconv.Itoa(id)]
+	if !ok {
+		return "", false
+	}
+	return IconURL(path), true
+}
@@ -0,0 +1,29 @@
+package hsr
+
+import "testing"
+
+// TestAssetURLs checks that icon paths resolve to EnkaNetwork URLs and unknown IDs are reported.
+func TestAssetURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"icon", IconURL("SpriteOutput/AvatarRoundIcon/1309.png"), "https://enka.network/ui/hsr/SpriteOutput/AvatarRoundIcon/1309.png"},
+		{"icon with leading slash", IconURL("/SpriteOutput/AvatarRoundIcon/1309.png"), "https://enka.network/ui/hsr/SpriteOutput/AvatarRoundIcon/1309.png"},
+		{"empty icon", IconURL(""), ""},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if url, ok := HeadIconURL(-1); ok || url != "" {
+		t.Errorf("HeadIconURL(-1) = %q, %v, want \"\", false", url, ok)
+	}
+	if url, ok := PersonalCardURL(-1); ok || url != "" {
+		t.Errorf("PersonalCardURL(-1) = %q, %v, want \"\", false", url, ok)
+	}
+}
@@ -0,0 +1,89 @@
+package hsr
+
+import "sort"
+
+// EidolonLevel returns the character's eidolon level (0-6), the number of unlocked
+// eidolons. It is stored in the Rank field.
+func (a *AvatarDetail) EidolonLevel() int {
+	return a.Rank
+}
+
+// IsAssist reports whether the character is set as the player's support character,
+// as indicated by the "_assist" field of the API response.
+func (a *AvatarDetail) IsAssist() bool {
+	return a.Assist
+}
+
+// SuperimpositionLevel returns the light cone's superimposition level (1-5), raised by
+// consuming duplicate copies. It is stored in the Rank field.
+func (e *Equipment) SuperimpositionLevel() int {
+	return e.Rank
+}
+
+// Property types of the base stats of a light cone, as found in Equipment.Flat.Props.
+const (
+	StatBaseHP      = "BaseHP"      // Base HP
+	StatBaseAttack  = "BaseAttack"  // Base ATK
+	StatBaseDefence = "BaseDefence" // Base DEF
+)
+
+// BaseStats returns the light cone's base stats at its current level and ascension,
+// keyed by property type (e.g., StatBaseHP). It returns an empty map if the light cone
+// has no flat data.
+func (e *Equipment) BaseStats() map[string]float64 {
+	stats := make(map[string]float64)
+	if e.Flat == nil {
+		return stats
+	}
+	for _, prop := range e.Flat.Props {
+		stats[prop.Type] += prop.Value
+	}
+	return stats
+}
+
+// StatByType returns the value of the light cone's stat of the given property type,
+// such as StatBaseAttack. It returns false if the light cone has no such stat or no
+// flat data.
+func (e *Equipment) StatByType(t string) (float64, bool) {
+	if e.Flat == nil {
+		return 0, false
+	}
+	for _, prop := range e.Flat.Props {
+		if prop.Type == t {
+			return prop.Value, true
+		}
+	}
+	return 0, false
+}
+
+// AvatarStats returns the stats granted to the character by its light cone and relics,
+// summed by property type and sorted by name (e.g., "CriticalChanceBase"). HSR
+// properties are only identified by type, so the ID of every entry is 0. The stats of
+// the character itself are not included, as the API does not provide them.
+func (a *AvatarDetail) AvatarStats() []StatEntry {
+	totals := make(map[string]float64)
+	if a.Equipment != nil && a.Equipment.Flat != nil {
+		for _, prop := range a.Equipment.Flat.Props {
+			totals[prop.Type] += prop.Value
+		}
+	}
+	for _, relic := range a.RelicList {
+		if relic.Flat == nil {
+			continue
+		}
+		for _, prop := range relic.Flat.Props {
+			totals[prop.Type] += prop.Value
+		}
+	}
+
+	stats := make([]StatEntry, 0, len(totals))
+	for name, value := range totals {
+		stats = append(stats, StatEntry{Name: name, Value: value})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+
+	return stats
+}
@@ -13,7 +13,6 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"reflect"
 	"testing"
 
 	"github.com/kirinyoku/enkanetwork-go/internal/core"
@@ -92,15 +91,7 @@ func TestGetProfile(t *testing.T) {
 	apiJSON = core.RemoveTTLField(apiJSON)
 	clientJSON = core.RemoveTTLField(clientJSON)
 
-	var apiMap, clientMap map[string]interface{}
-	if err := json.Unmarshal(apiJSON, &apiMap); err != nil {
-		t.Fatalf("failed to unmarshal API JSON: %v", err)
-	}
-	if err := json.Unmarshal(clientJSON, &clientMap); err != nil {
-		t.Fatalf("failed to unmarshal client JSON: %v", err)
-	}
-
-	if !reflect.DeepEqual(apiMap, clientMap) {
-		t.Errorf("JSON responses do not match. API JSON: %s\nClient JSON: %s", apiJSON, clientJSON)
+	if missing := core.DiffJSONKeys(apiJSON, clientJSON); len(missing) > 0 {
+		t.Errorf("API response contains fields missing from client structs: %v", missing)
 	}
 }
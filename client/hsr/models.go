@@ -1,6 +1,11 @@
 package hsr
 
-import "github.com/kirinyoku/enkanetwork-go/models"
+import (
+	"encoding/json"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/jsonutil"
+	"github.com/kirinyoku/enkanetwork-go/models"
+)
 
 // Profile represents the root structure of the response containing player information
 // and character data. It serves as the main container for all data returned by the
@@ -23,15 +28,46 @@ type Profile struct {
 	UID string `json:"uid,omitempty"`
 	// Region indicates the server region of the player (e.g., "ASIA", "USA", "EUROPE")
 	Region string `json:"region,omitempty"`
+	// Extra contains the top-level fields of the API response that are not mapped to
+	// any field of this struct. It allows reading newly added properties before the
+	// library is updated to support them
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Profile. It decodes
+// the known fields of a profile as usual and stores the other top-level fields in Extra.
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	type profile Profile
+
+	var aux profile
+	extra, err := jsonutil.UnmarshalExtra(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	*p = Profile(aux)
+	p.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Profile. The fields in Extra are
+// encoded along with the others, so they survive a round trip.
+func (p Profile) MarshalJSON() ([]byte, error) {
+	type profile Profile
+	return jsonutil.MarshalExtra(profile(p), p.Extra)
 }
 
+// hoyoType is the hoyo_type value of builds and hoyos belonging to Honkai: Star Rail accounts.
+const hoyoType = 1
+
 // Build contains information about a specific character build in Honkai: Star Rail.
 type Build struct {
-	ID         int           `json:"id,omitempty"`          // Unique identifier for the build
-	Name       string        `json:"name,omitempty"`        // Name of the build
-	AvatarID   string        `json:"avatar_id,omitempty"`   // ID of the character
-	AvatarData *AvatarDetail `json:"avatar_data,omitempty"` // Character data (*hsr.AvatarDetail)
-	Order      int           `json:"order,omitempty"`       // Order of the saved build on the Enka
+	ID         int           `json:"id,omitempty"`           // Unique identifier for the build
+	Name       string        `json:"name,omitempty"`         // Name of the build
+	AvatarID   string        `json:"avatar_id,omitempty"`    // ID of the character
+	AvatarData *AvatarDetail `json:"avatar_data,omitempty"`  // Character data (*hsr.AvatarDetail)
+	Order      int           `json:"order,string,omitempty"` // Order of the saved build on the Enka, sent as a string by the API
 	// If a build has a live: true field, it indicates it is not a saved build but one
 	// retrieved from the game’s showcase when the "refresh" button is clicked. During
 	// an update, all old live builds are deleted, and new ones are created. Updates
@@ -48,16 +84,16 @@ type Build struct {
 type DetailInfo struct {
 	WorldLevel         int                 `json:"worldLevel,omitempty"`         // Player's current world level
 	PrivacySettingInfo *PrivacySettingInfo `json:"privacySettingInfo,omitempty"` // Player's privacy settings
-	HeadIcon           int                 `json:"headIcon,omitempty"`           // ID of the player's profile icon
+	HeadIcon           int                 `json:"headIcon,omitempty"`           // ID of the player's profile icon (see HeadIconURL)
 	AvatarDetailList   []AvatarDetail      `json:"avatarDetailList,omitempty"`   // List of detailed character information
-	Platform           string              `json:"platform,omitempty"`           // Platform where the account is registered
+	Platform           string              `json:"platform,omitempty"`           // Platform where the account is registered; see models.PlatformFromString
 	RecordInfo         *RecordInfo         `json:"recordInfo,omitempty"`         // Player's achievement and collection records
 	UID                int                 `json:"uid,omitempty"`                // Player's unique identifier
 	Level              int                 `json:"level,omitempty"`              // Player's account level
 	Nickname           string              `json:"nickname,omitempty"`           // Player's chosen nickname
 	IsDisplayAvatar    bool                `json:"isDisplayAvatar,omitempty"`    // Whether the player's avatar is displayed
 	FriendCount        int                 `json:"friendCount,omitempty"`        // Number of friends the player has
-	PersonalCardID     int                 `json:"personalCardId,omitempty"`     // ID of the player's personal card
+	PersonalCardID     int                 `json:"personalCardId,omitempty"`     // ID of the player's personal card (see PersonalCardURL)
 
 	// There is no information about this field in the EnkaNetwork HSR API doc.
 	// In practice, I always get its value as []map.
@@ -106,7 +142,7 @@ type SubAffix struct {
 // Flat contains flat data for relics and equipment.
 type Flat struct {
 	Props   []models.Prop `json:"props,omitempty"`   // List of properties
-	SetName uint64        `json:"setName,omitempty"` // Name of the set
+	SetName uint64        `json:"setName,omitempty"` // Text map hash of the set's name
 	SetID   int           `json:"setID,omitempty"`   // ID of the set
 }
 
@@ -133,21 +169,55 @@ type Equipment struct {
 
 // RecordInfo contains various achievement and collection records for the player.
 type RecordInfo struct {
-	AchievementCount       int  `json:"achievementCount,omitempty"`       // Number of achievements completed
-	BookCount              int  `json:"bookCount,omitempty"`              // Number of books collected
-	AvatarCount            int  `json:"avatarCount,omitempty"`            // Number of characters unlocked
-	EquipmentCount         int  `json:"equipmentCount,omitempty"`         // Number of equipment pieces
-	MusicCount             int  `json:"musicCount,omitempty"`             // Number of music tracks
-	RelicCount             int  `json:"relicCount,omitempty"`             // Number of relics collected
-	ChallengeInfo          *any `json:"challengeInfo,omitempty"`          // Challenge-related information
-	MaxRogueChallengeScore int  `json:"maxRogueChallengeScore,omitempty"` // Highest score in rogue challenges
-}
-
-// Settings represents build-specific configuration options.
-type Settings struct {
-	AdaptiveColor *bool    `json:"adaptiveColor,omitempty"` // Whether adaptive color is enabled
-	ArtSource     *string  `json:"artSource,omitempty"`     // Source of the image
-	Caption       *string  `json:"caption,omitempty"`       // Caption of the build
-	HonkardWidth  *float64 `json:"honkardWidth,omitempty"`  // Width of the image
-	Transform     *string  `json:"transform,omitempty"`     // Transformation applied to the image
+	AchievementCount       int            `json:"achievementCount,omitempty"`       // Number of achievements completed
+	BookCount              int            `json:"bookCount,omitempty"`              // Number of books collected
+	AvatarCount            int            `json:"avatarCount,omitempty"`            // Number of characters unlocked
+	EquipmentCount         int            `json:"equipmentCount,omitempty"`         // Number of equipment pieces
+	MusicCount             int            `json:"musicCount,omitempty"`             // Number of music tracks
+	RelicCount             int            `json:"relicCount,omitempty"`             // Number of relics collected
+	ChallengeInfo          *ChallengeInfo `json:"challengeInfo,omitempty"`          // Endgame challenge progress
+	MaxRogueChallengeScore int            `json:"maxRogueChallengeScore,omitempty"` // Highest score in rogue challenges
+}
+
+// ChallengeInfo contains the player's progress in endgame challenges.
+type ChallengeInfo struct {
+	ScheduleMaxLevel     int `json:"scheduleMaxLevel,omitempty"`     // Highest Memory of Chaos stage cleared in the current schedule
+	ScheduleGroupID      int `json:"scheduleGroupId,omitempty"`      // ID of the current Memory of Chaos schedule
+	NoneScheduleMaxLevel int `json:"noneScheduleMaxLevel,omitempty"` // Highest Forgotten Hall: Memory stage cleared
+	// Extra contains the fields of the challenge information that are not mapped to any
+	// field of this struct, such as the progress in newer game modes (e.g., Pure Fiction
+	// or Apocalyptic Shadow) that are not documented yet
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for ChallengeInfo. It decodes
+// the documented challenge fields and keeps the progress of other game modes in Extra.
+func (c *ChallengeInfo) UnmarshalJSON(data []byte) error {
+	type challengeInfo ChallengeInfo
+
+	var aux challengeInfo
+	extra, err := jsonutil.UnmarshalExtra(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	*c = ChallengeInfo(aux)
+	c.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for ChallengeInfo. The fields in Extra are
+// encoded along with the others, so they survive a round trip.
+func (c ChallengeInfo) MarshalJSON() ([]byte, error) {
+	type challengeInfo ChallengeInfo
+	return jsonutil.MarshalExtra(challengeInfo(c), c.Extra)
 }
+
+// Settings represents the display settings of a build. It is the same type in all the
+// game packages.
+type Settings = models.BuildSettings
+
+// StatEntry is a single stat of a character (see AvatarStats). It is the same type in all
+// the game packages.
+type StatEntry = models.StatEntry
@@ -0,0 +1,54 @@
+package hsr
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeProfile decodes a profile from the JSON body of an API response. It performs
+// the same decoding as GetProfile, including filling Profile.Extra, and is meant for
+// applications that obtain the response themselves, e.g. through their own proxy, and
+// only need the models of this package.
+//
+// Example:
+//
+//	profile, err := hsr.DecodeProfile(body)
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+func DecodeProfile(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to decode profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// ShowcasedAvatarIDs returns the IDs of the characters in the player's showcase, in
+// showcase order, as listed in DetailInfo.AvatarDetailList. It returns an empty slice
+// if the profile has no DetailInfo.
+func (p *Profile) ShowcasedAvatarIDs() []int {
+	if p.DetailInfo == nil {
+		return []int{}
+	}
+
+	ids := make([]int, 0, len(p.DetailInfo.AvatarDetailList))
+	for _, avatar := range p.DetailInfo.AvatarDetailList {
+		ids = append(ids, avatar.AvatarID)
+	}
+	return ids
+}
+
+// ShowcaseHidden reports whether the player has hidden the characters in their
+// showcase. It is inferred from DetailInfo.IsDisplayAvatar, which is false when the
+// player has disabled displaying their characters, together with AvatarDetailList being
+// empty; characters listed despite the flag are treated as visible. It returns false if
+// the profile has no DetailInfo.
+func (p *Profile) ShowcaseHidden() bool {
+	if p.DetailInfo == nil {
+		return false
+	}
+	return !p.DetailInfo.IsDisplayAvatar && len(p.DetailInfo.AvatarDetailList) == 0
+}
@@ -0,0 +1,98 @@
+package hsr
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+)
+
+// TestDecodeProfile checks that a captured API response decodes into the expected values.
+func TestDecodeProfile(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	profile, err := DecodeProfile(data)
+	if err != nil {
+		t.Fatalf("failed to decode profile: %v", err)
+	}
+
+	character := profile.DetailInfo.AvatarDetailList[0]
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"nickname", profile.DetailInfo.Nickname, "Trailblazer"},
+		{"uid", profile.DetailInfo.UID, 807752192},
+		{"showcased avatars", profile.ShowcasedAvatarIDs(), []int{1309}},
+		{"showcase hidden", profile.ShowcaseHidden(), false},
+		{"character level", character.Level, 80},
+		{"eidolon level", character.EidolonLevel(), 2},
+		{"assist", character.IsAssist(), true},
+		{"relic main affix", character.RelicList[0].MainAffixID, 1},
+		{"relic set", character.RelicList[0].Flat.SetID, 116},
+		{"relic substat rolls", character.RelicList[0].SubstatRolls(), map[int]int{8: 3, 9: 2}},
+		{"relic sets", character.ActiveRelicSets(), []RelicSet{{SetID: 116, SetNameHash: 2474466151, Count: 2}}},
+		{"memory of chaos", profile.DetailInfo.RecordInfo.ChallengeInfo.ScheduleMaxLevel, 12},
+		{"forgotten hall", profile.DetailInfo.RecordInfo.ChallengeInfo.NoneScheduleMaxLevel, 15},
+		{"light cone superimposition", character.Equipment.SuperimpositionLevel(), 1},
+		{"light cone base stats", character.Equipment.BaseStats(), map[string]float64{StatBaseHP: 1058.4}},
+		{"stats", character.AvatarStats(), []StatEntry{{Name: "AttackDelta", Value: 352.8}, {Name: StatBaseHP, Value: 1058.4}, {Name: "CriticalChanceBase", Value: 0.0972}, {Name: "CriticalDamageBase", Value: 0.0777}, {Name: "HPDelta", Value: 705.6}}},
+		{"ttl", profile.TTL, 90},
+		{"extra", len(profile.Extra), 0},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	relic := &character.RelicList[0]
+	if value, ok := relic.SubstatValue(9); !ok || value != 0.0777 {
+		t.Errorf("SubstatValue(9) = %v, %v, want 0.0777, true", value, ok)
+	}
+	if _, ok := relic.SubstatValue(1); ok {
+		t.Error("expected the main stat not to be returned as a substat")
+	}
+
+	if value, ok := character.Equipment.StatByType(StatBaseHP); !ok || value != 1058.4 {
+		t.Errorf("StatByType(StatBaseHP) = %v, %v, want 1058.4, true", value, ok)
+	}
+	if _, ok := character.Equipment.StatByType(StatBaseAttack); ok {
+		t.Error("expected no base ATK in the fixture")
+	}
+
+	encoded, err := json.Marshal(profile)
+	if err != nil {
+		t.Fatalf("failed to encode profile: %v", err)
+	}
+	if missing := core.DiffJSONKeys(data, encoded); len(missing) > 0 {
+		t.Errorf("fixture contains fields missing from the structs: %v", missing)
+	}
+	if got, want := core.NormalizeJSON(encoded), core.NormalizeJSON(data); !bytes.Equal(got, want) {
+		t.Errorf("re-encoded profile differs from the fixture:\n got %s\nwant %s", got, want)
+	}
+}
+
+// TestDecodeChallengeInfo checks that undocumented challenge fields are preserved in Extra.
+func TestDecodeChallengeInfo(t *testing.T) {
+	var info ChallengeInfo
+	if err := json.Unmarshal([]byte(`{"scheduleMaxLevel":12,"storyMaxLevel":4}`), &info); err != nil {
+		t.Fatalf("failed to decode challenge info: %v", err)
+	}
+
+	if info.ScheduleMaxLevel != 12 {
+		t.Errorf("expected ScheduleMaxLevel 12, got %d", info.ScheduleMaxLevel)
+	}
+	if string(info.Extra["storyMaxLevel"]) != "4" {
+		t.Errorf("expected storyMaxLevel to be kept in Extra, got %v", info.Extra)
+	}
+}
@@ -0,0 +1,59 @@
+package hsr
+
+// subAffixPropTypes maps the IDs of relic sub-affixes (SubAffix.AffixID) to the type of
+// the property they add to the relic's flat data.
+var subAffixPropTypes = map[int]string{
+	1:  "HPDelta",
+	2:  "AttackDelta",
+	3:  "DefenceDelta",
+	4:  "HPAddedRatio",
+	5:  "AttackAddedRatio",
+	6:  "DefenceAddedRatio",
+	7:  "SpeedDelta",
+	8:  "CriticalChanceBase",
+	9:  "CriticalDamageBase",
+	10: "StatusProbabilityBase",
+	11: "StatusResistanceBase",
+	12: "BreakDamageAddedRatioBase",
+}
+
+// SubstatRolls returns the number of times each substat of the relic was rolled, keyed
+// by the sub-affix ID. The count includes the roll that added the substat to the relic.
+func (r *Relic) SubstatRolls() map[int]int {
+	rolls := make(map[int]int, len(r.SubAffixList))
+	for _, affix := range r.SubAffixList {
+		rolls[affix.AffixID] += affix.Cnt
+	}
+	return rolls
+}
+
+// SubstatValue returns the total value of the given substat, as provided in the relic's
+// flat data (e.g., 0.0972 for 9.72% CRIT Rate). It returns false if the relic has no
+// such substat or no flat data.
+//
+// Flat.Props lists the main stat first, followed by the substats, so the main stat is
+// never returned even if it has the same type as the substat.
+func (r *Relic) SubstatValue(affixID int) (float64, bool) {
+	propType, ok := subAffixPropTypes[affixID]
+	if !ok || r.Flat == nil || len(r.Flat.Props) == 0 {
+		return 0, false
+	}
+
+	hasAffix := false
+	for _, affix := range r.SubAffixList {
+		if affix.AffixID == affixID {
+			hasAffix = true
+			break
+		}
+	}
+	if !hasAffix {
+		return 0, false
+	}
+
+	for _, prop := range r.Flat.Props[1:] {
+		if prop.Type == propType {
+			return prop.Value, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,71 @@
+package hsr
+
+import "sort"
+
+// RelicSet describes a relic set equipped on a character with enough pieces to
+// activate at least its 2-piece bonus.
+//
+// The API does not provide the localized name of the set, only the text map hash of
+// the name in the relic's flat data. Resolve SetNameHash with the Honkai: Star Rail
+// localization file to display the name; it is not meant to be shown as is.
+type RelicSet struct {
+	SetID       int    // ID of the relic set
+	SetNameHash uint64 // Text map hash of the set's name, from the relic's flat data (0 if not present)
+	Count       int    // Number of equipped relics belonging to the set
+}
+
+// TwoPiece reports whether the 2-piece bonus of the set is active.
+func (s RelicSet) TwoPiece() bool {
+	return s.Count >= 2
+}
+
+// FourPiece reports whether the 4-piece bonus of the set is active. Planar
+// ornament sets only have a 2-piece bonus, so this is never true for them.
+func (s RelicSet) FourPiece() bool {
+	return s.Count >= 4
+}
+
+// RelicSetCounts groups the relics equipped on the character by their set ID
+// (Flat.SetID) and returns the number of relics of each set. Relics without flat
+// data are skipped.
+func (a *AvatarDetail) RelicSetCounts() map[int]int {
+	counts := make(map[int]int)
+	for _, relic := range a.RelicList {
+		if relic.Flat == nil {
+			continue
+		}
+		counts[relic.Flat.SetID]++
+	}
+	return counts
+}
+
+// ActiveRelicSets returns the relic sets with at least 2 equipped pieces, i.e. the
+// sets whose 2-piece (and possibly 4-piece) bonus is active. The result is sorted
+// by set ID.
+//
+// Example:
+//
+//	for _, set := range character.ActiveRelicSets() {
+//	    fmt.Println(set.SetID, set.Count, set.FourPiece())
+//	}
+func (a *AvatarDetail) ActiveRelicSets() []RelicSet {
+	names := make(map[int]uint64)
+	for _, relic := range a.RelicList {
+		if relic.Flat != nil && relic.Flat.SetName != 0 {
+			names[relic.Flat.SetID] = relic.Flat.SetName
+		}
+	}
+
+	var sets []RelicSet
+	for setID, count := range a.RelicSetCounts() {
+		if count >= 2 {
+			sets = append(sets, RelicSet{SetID: setID, SetNameHash: names[setID], Count: count})
+		}
+	}
+
+	sort.Slice(sets, func(i, j int) bool {
+		return sets[i].SetID < sets[j].SetID
+	})
+
+	return sets
+}
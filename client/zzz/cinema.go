@@ -0,0 +1,30 @@
+package zzz
+
+import "slices"
+
+// ActiveCinemaToggles returns the indices of TalentToggleList that are true, i.e. the
+// Mindscape Cinema nodes whose visual is toggled on in the agent's showcase. Index i
+// corresponds to Mindscape Cinema i+1. Only unlocked nodes (up to TalentLevel) can be
+// toggled. It returns an empty slice if no node is toggled on.
+//
+// Example:
+//
+//	for _, i := range agent.ActiveCinemaToggles() {
+//	    fmt.Println("Mindscape Cinema", i+1, "is shown")
+//	}
+func (a *AvatarData) ActiveCinemaToggles() []int {
+	toggles := []int{}
+	for i, on := range a.TalentToggleList {
+		if on {
+			toggles = append(toggles, i)
+		}
+	}
+	return toggles
+}
+
+// HasClaimedPromotionReward reports whether the reward of the given promotion level
+// has been claimed. ClaimedRewardList holds the promotion levels (1-5) whose rewards
+// were claimed, so an agent with every reward claimed has [1, 2, 3, 4, 5].
+func (a *AvatarData) HasClaimedPromotionReward(promotionLevel int) bool {
+	return slices.Contains(a.ClaimedRewardList, promotionLevel)
+}
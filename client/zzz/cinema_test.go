@@ -0,0 +1,34 @@
+package zzz
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCinemaAndRewards(t *testing.T) {
+	agent := &AvatarData{
+		TalentToggleList:  []bool{true, false, true, false, false, false},
+		ClaimedRewardList: []int{1, 2, 3},
+	}
+
+	if got, want := agent.ActiveCinemaToggles(), []int{0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ActiveCinemaToggles() = %v, want %v", got, want)
+	}
+	if got := (&AvatarData{}).ActiveCinemaToggles(); len(got) != 0 {
+		t.Errorf("ActiveCinemaToggles() of an agent without toggles = %v, want []", got)
+	}
+
+	tests := []struct {
+		level int
+		want  bool
+	}{
+		{1, true},
+		{3, true},
+		{4, false},
+	}
+	for _, tt := range tests {
+		if got := agent.HasClaimedPromotionReward(tt.level); got != tt.want {
+			t.Errorf("HasClaimedPromotionReward(%d) = %v, want %v", tt.level, got, tt.want)
+		}
+	}
+}
@@ -2,12 +2,14 @@ package zzz
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/http"
-	"time"
+	"strconv"
 
+	"github.com/kirinyoku/enkanetwork-go/cache"
 	"github.com/kirinyoku/enkanetwork-go/internal/core"
 	"github.com/kirinyoku/enkanetwork-go/internal/core/fetcher"
+	"github.com/kirinyoku/enkanetwork-go/models"
 )
 
 // Client extends core.Client to provide ZZZ-specific functionality for player
@@ -22,26 +24,72 @@ import (
 // Create a Client using the NewClient function, which allows customization of these
 // settings. Once created, use the Client to call methods like GetProfile to fetch
 // player data.
+//
+// A Client is safe for concurrent use by multiple goroutines, and should be reused
+// rather than created for each request.
 type Client struct {
-	*core.Client // Embeds core.Client for shared HTTP and caching functionality
-	fetcher      *fetcher.Fetcher[Profile]
+	*core.Client  // Embeds core.Client for shared HTTP and caching functionality
+	fetcher       *fetcher.Fetcher[Profile]
+	buildsFetcher *fetcher.Fetcher[map[string][]Build]
+}
+
+// init registers the types of the values cached by the client, so that a
+// cache.FileCache can persist them.
+func init() {
+	cache.Register(&Profile{})
+	cache.Register([]Build{})
+	cache.Register(core.NotFound{})
 }
 
-// NewClient creates a new Zenless Zone Zero API client for making requests.
+// New creates a new Zenless Zone Zero API client configured with the given options.
 //
-// This function allows you to customize the client by providing your own HTTP client,
-// cache implementation, and User-Agent string. If you don't provide these, default
-// values are used: a standard HTTP client with a 10-second timeout, no cache, and
-// a default User-Agent of "enkanetwork-go-client/1.0".
+// Options allow you to customize the client by providing your own HTTP client, cache
+// implementation, User-Agent string or retry configuration. Options that are not
+// provided fall back to default values: a standard HTTP client with a 10-second
+// timeout, no cache, DefaultUserAgent as the User-Agent, and up to 3 attempts for
+// requests failing with a transient error.
 //
-// Parameters:
-//   - httpClient: An optional *http.Client for making HTTP requests. If nil, a default
-//     client with a 10-second timeout is used.
-//   - cache: An optional Cache implementation for storing responses. If nil, caching
-//     is disabled.
-//   - userAgent: A string to set as the User-Agent header in requests. If empty, the
-//     default "enkanetwork-go-client/1.0" is used. It's recommended to set a unique
-//     User-Agent to identify your application, such as "my-app/1.0".
+// Available options:
+//   - WithHTTPClient: A custom *http.Client for making HTTP requests.
+//   - WithHTTPDoer: A custom HTTPDoer sending the requests, e.g. a mock in tests.
+//   - WithTransport: A custom http.RoundTripper, such as one using a proxy or client
+//     certificates, installed on the default HTTP client.
+//   - WithCache: A Cache implementation for storing responses.
+//   - WithUserAgent: A string to set as the User-Agent header in requests. It's
+//     recommended to set a unique User-Agent to identify your application, such as
+//     "my-app/1.0".
+//   - WithUserAgentSuffix: A string appended to the User-Agent, e.g. to identify your
+//     application while keeping the library's User-Agent.
+//   - WithRetryConfig: The number of attempts and the default delay between them.
+//   - WithNoRetry: Disables retries, so errors are returned after a single attempt.
+//   - WithRetryOnMaintenance: Retries requests failing with 424 during maintenance.
+//   - WithRetryBudget: A budget of retries shared across requests and clients.
+//   - WithClock: A fake clock for tests, controlling the delays between retries.
+//   - WithRequireUserAgent: Makes a custom User-Agent mandatory.
+//   - WithRateLimiter: A rate limiter waited on before every request, such as
+//     rate.NewLimiter from golang.org/x/time/rate.
+//   - WithMaxConcurrency: The maximum number of requests in flight at once.
+//   - WithRequestTimeout: A timeout for each request attempt, without the need for a
+//     custom HTTP client.
+//   - WithFallbackTimeout: The timeout of request attempts that nothing else bounds,
+//     30 seconds by default.
+//   - WithMaxResponseSize: The maximum size of a response body, 16 MB by default.
+//   - WithAPIVersion: A version prefix for the endpoint paths, e.g. "v2".
+//   - WithLanguage: The language of localized responses, e.g. "ja".
+//   - WithConditionalRequests: Sends If-None-Match with the ETag of the last response
+//     and reuses its body when the API answers 304 Not Modified.
+//   - WithHeaders: Additional headers sent with every request, e.g. for an auth proxy.
+//   - WithMinCacheTTL, WithMaxCacheTTL: Bounds of how long responses are cached.
+//   - WithCacheErrorHandler: A function called when a cached value cannot be used.
+//   - WithNotFoundTTL: How long lookups of UIDs without a player are cached.
+//   - WithServeStaleOnError: Returns expired cached values when a request fails with a
+//     transient error, along with ErrStaleData.
+//
+// The User-Agent is validated when the client is created. If it is invalid, or missing
+// while WithRequireUserAgent is used, Err returns ErrInvalidUserAgent or
+// ErrUserAgentRequired, and every request fails with the same error.
+// Likewise, a language set with WithLanguage that is not supported by EnkaNetwork makes
+// Err and every request return ErrInvalidLanguage.
 //
 // Returns:
 //   - A pointer to a new ZZZ-specific Client instance ready to make API requests.
@@ -49,19 +97,30 @@ type Client struct {
 // Example:
 //
 //	// Create a client with default settings
-//	client := zzz.NewClient(nil, nil, "my-app/1.0")
+//	client := zzz.New(zzz.WithUserAgent("my-app/1.0"))
 //	// Create a client with a custom HTTP client
 //	customClient := &http.Client{Timeout: 20 * time.Second}
-//	client := zzz.NewClient(customClient, nil, "my-app/1.0")
-func NewClient(httpClient *http.Client, cache core.Cache, userAgent string) *Client {
-	c := core.NewClient(httpClient, cache, userAgent)
+//	client := zzz.New(zzz.WithHTTPClient(customClient), zzz.WithUserAgent("my-app/1.0"))
+func New(opts ...Option) *Client {
+	c := core.New(opts...)
 
 	return &Client{
-		Client:  c,
-		fetcher: fetcher.NewFetcher[Profile](c.HTTPClient, c.UserAgent),
+		Client:        c,
+		fetcher:       fetcher.NewFetcher[Profile](c),
+		buildsFetcher: fetcher.NewFetcher[map[string][]Build](c),
 	}
 }
 
+// NewClient creates a new Zenless Zone Zero API client with the given HTTP client, cache and
+// User-Agent. Zero values fall back to the same defaults as New.
+//
+// Deprecated: Use New with the WithHTTPClient, WithCache and WithUserAgent options
+// instead. NewClient is kept for backward compatibility and will not receive new
+// configuration parameters.
+func NewClient(httpClient *http.Client, cache core.Cache, userAgent string) *Client {
+	return New(WithHTTPClient(httpClient), WithCache(cache), WithUserAgent(userAgent))
+}
+
 // GetProfile fetches the full player profile for the given UID using EnkaNetwork API.
 // The profile includes detailed information about the player, such as their nickname,
 // level, agents, equipment, etc., as defined in the Profile struct.
@@ -74,24 +133,29 @@ func NewClient(httpClient *http.Client, cache core.Cache, userAgent string) *Cli
 // If the request is successful, the profile is cached locally using the ttl value
 // returned by the API, which indicates how long the data remains valid before the
 // API queries the game again. Caching helps reduce the number of requests and
-// respects the API's rate limits.
+// respects the API's rate limits. Responses with a ttl of zero or less are not cached.
 //
 // Parameters:
 //   - ctx: A context.Context to control the request's timeout or cancellation. For
 //     example, you can use context.WithTimeout to set a maximum duration for the request.
-//   - uid: The player's UID, which must be a 9 or 10-digit string (e.g., "1301806568").
+//   - uid: The player's UID, which must be a 9 or 10-digit string (see IsValidUID).
 //
 // Returns:
 //   - *Profile: A pointer to the Profile struct if the request is successful.
 //   - error: An error if the request fails.
 //
 // Possible errors include:
-//   - ErrInvalidUIDFormat: If the UID is not a 9 or 10-digit number.
+//   - ErrInvalidUIDFormat: If the UID is not a valid UID (see IsValidUID).
 //   - ErrPlayerNotFound: If the player does not exist.
-//   - ErrRateLimited: If the rate limit is exceeded after retries.
-//   - ErrServerMaintenance: If the API is under maintenance.
+//   - ErrProfileNotCachedYet: If the player exists but the API has no data yet; retry later.
+//   - ErrRateLimited: If the rate limit is exceeded after retries. If the API asked to
+//     wait, it is wrapped in an *APIError holding the requested delay.
+//   - ErrServerMaintenance: If the API is under maintenance. If the API announced its
+//     end, it is wrapped in an *APIError holding it in MaintenanceUntil.
 //   - ErrServerError: For general server errors.
 //   - ErrServiceUnavailable: If the API is completely unavailable.
+//   - ErrTruncatedResponse: If every attempt returned an incomplete response body.
+//   - ErrResponseTooLarge: If the response body exceeds the maximum response size.
 //
 // Example:
 //
@@ -104,46 +168,192 @@ func NewClient(httpClient *http.Client, cache core.Cache, userAgent string) *Cli
 //	fmt.Println("Player Nickname:", profile.PlayerInfo.SocialDetail.ProfileDetail.Nickname)
 //	fmt.Println("World Level:", profile.PlayerInfo.SocialDetail.ProfileDetail.Level)
 func (c *Client) GetProfile(ctx context.Context, uid string) (*Profile, error) {
-	if !isValidUID(uid) {
+	if !IsValidUID(uid) {
 		return nil, ErrInvalidUIDFormat
 	}
 
-	key := fmt.Sprintf("zzz_%s", uid)
+	key := core.CacheKey("zzz", uid)
 
-	if c.Cache != nil {
+	if c.Cache != nil && !core.BypassCacheFromContext(ctx) {
 		if cached, ok := c.Cache.Get(key); ok {
-			if profile, ok := cached.(*Profile); ok {
+			if profile, ok := core.CachedAs[*Profile](c.Client, key, cached); ok {
 				return profile, nil
 			}
 		}
+		if err := c.CachedNotFound(key); err != nil {
+			return nil, err
+		}
 	}
 
-	url := fmt.Sprintf("%s/zzz/uid/%s", core.BaseURL, uid)
-	profile, err := c.fetcher.FetchWithRetry(ctx, url)
-	if err == nil && c.Cache != nil {
-		c.Cache.Set(key, profile, time.Duration(profile.TTL)*time.Second)
+	url := c.URL(core.ZZZUIDPath(uid))
+	return core.Do(ctx, c.Client, key, func(ctx context.Context) (*Profile, error) {
+		profile, err := c.fetcher.FetchWithRetry(ctx, url)
+		if err != nil {
+			c.CacheNotFound(key, err)
+			return core.StaleOnError[*Profile](c.Client, key, err)
+		}
+
+		if c.Cache != nil {
+			if ttl, ok := c.CacheTTL(profile.TTL); ok {
+				c.Cache.Set(key, profile, ttl)
+			}
+		}
+
+		return profile, nil
+	})
+}
+
+// GetProfileByID is like GetProfile, but takes the UID as an integer, e.g. one decoded
+// from another API response, instead of a string.
+//
+// The UID is formatted in base 10 and validated like a string UID (see IsValidUID): it
+// must have 9 or 10 digits. Use an int64 to hold 10-digit UIDs, which may not fit in
+// an int32. Negative and out-of-range values return ErrInvalidUIDFormat without making
+// a request.
+//
+// Example:
+//
+//	profile, err := client.GetProfileByID(ctx, 1301806568)
+func (c *Client) GetProfileByID(ctx context.Context, uid int64) (*Profile, error) {
+	if uid < 0 {
+		return nil, ErrInvalidUIDFormat
 	}
 
-	return profile, err
+	return c.GetProfile(ctx, strconv.FormatInt(uid, 10))
 }
 
-// isValidUID checks if the provided UID is a valid 9 or 10-digit number.
-// ZZZ UID can only be 9 or 10 digits (e.g., "1301806568").
-// This function is used internally to validate UIDs before making requests.
+// GetProfileRaw fetches the full player profile for the given UID and returns the
+// undecoded JSON response body.
+//
+// The API periodically adds new fields before the Profile struct is updated to include
+// them. GetProfileRaw lets you read such fields yourself: the request goes through the
+// same retry and error handling as GetProfile, but the body is returned as-is instead
+// of being decoded. Raw responses are not cached.
 //
 // Parameters:
-//   - uid: The UID string to validate.
+//   - ctx: A context.Context to control the request's timeout or cancellation.
+//   - uid: The player's UID, which must be a 9 or 10-digit string (see IsValidUID).
 //
 // Returns:
-//   - true if the UID is a 9 or 10-digit number, false otherwise.
-func isValidUID(uid string) bool {
-	if len(uid) != 9 && len(uid) != 10 {
-		return false
+//   - json.RawMessage: The raw JSON response body if the request is successful.
+//   - error: An error if the request fails.
+//
+// Possible errors include:
+//   - ErrInvalidUIDFormat: If the UID is not a valid UID (see IsValidUID).
+//   - ErrPlayerNotFound: If the player does not exist.
+//   - ErrProfileNotCachedYet: If the player exists but the API has no data yet; retry later.
+//   - ErrRateLimited: If the rate limit is exceeded after retries. If the API asked to
+//     wait, it is wrapped in an *APIError holding the requested delay.
+//   - ErrServerMaintenance: If the API is under maintenance. If the API announced its
+//     end, it is wrapped in an *APIError holding it in MaintenanceUntil.
+//   - ErrServerError: For general server errors.
+//   - ErrServiceUnavailable: If the API is completely unavailable.
+//
+// Example:
+//
+//	ctx := context.Background()
+//	raw, err := client.GetProfileRaw(ctx, "1301806568")
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+//	var data map[string]any
+//	_ = json.Unmarshal(raw, &data)
+func (c *Client) GetProfileRaw(ctx context.Context, uid string) (json.RawMessage, error) {
+	if !IsValidUID(uid) {
+		return nil, ErrInvalidUIDFormat
 	}
-	for _, r := range uid {
-		if r < '0' || r > '9' {
-			return false
-		}
+
+	url := c.URL(core.ZZZUIDPath(uid))
+
+	return c.fetcher.FetchRaw(ctx, url)
+}
+
+// GetBuilds fetches the builds saved on Enka for a Zenless Zone Zero account linked to an Enka
+// user profile.
+//
+// Unlike enka.Client.GetUserProfileHoyoBuilds, which returns builds of any game wrapped
+// in enka.AvatarDataWrapper, this method decodes the builds directly into the Build type
+// of this package. Builds belonging to other games are skipped, so the result is empty
+// if the hoyo hash refers to an account of a different game.
+//
+// The returned slice is ordered by avatar ID. Builds of the same character keep the
+// order in which they were returned by the API.
+//
+// The response is cached for a fixed duration of 5 minutes, since builds do not include
+// a TTL value. WithMinCacheTTL and WithMaxCacheTTL adjust this duration.
+//
+// Parameters:
+//   - ctx: A context.Context to control the request's timeout or cancellation.
+//   - username: The username of the EnkaNetwork user (see enka.IsValidUsername).
+//   - hoyoHash: The hash of the hoyo (see enka.IsValidHoyoHash), as returned by
+//     enka.Client.GetUserProfileHoyos.
+//
+// Returns:
+//   - []Build: The builds of the account.
+//   - error: An error if the request fails.
+//
+// Possible errors include:
+//   - ErrInvalidUsername: If the username is empty or invalid.
+//   - ErrInvalidHoyoHash: If the hoyo hash is empty or invalid.
+//   - ErrHoyoAccountBuildsNotFound: If the user or hoyo account does not exist.
+//
+// Example:
+//
+//	ctx := context.Background()
+//	builds, err := client.GetBuilds(ctx, "Algoinde", "4Wjv2e")
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+//	for _, build := range builds {
+//	    fmt.Println(build.AvatarID, build.Name)
+//	}
+func (c *Client) GetBuilds(ctx context.Context, username string, hoyoHash string) ([]Build, error) {
+	return core.GetBuilds(ctx, c.Client, "zzz", username, hoyoHash, c.buildsFetcher.FetchWithRetry, func(build Build) bool {
+		return build.HoyoType == hoyoType
+	})
+}
+
+// GetOwner returns the Enka user profile that owns the game account with the given UID.
+//
+// The owner is taken from the full player profile fetched with GetProfile, so the same
+// cache entry is used and calling GetOwner after GetProfile (or vice versa) does not
+// make an additional request.
+//
+// An owner is only present if the user has an Enka account, has added and verified the
+// UID on their profile, and has set their profile visibility to public.
+//
+// Parameters:
+//   - ctx: A context.Context to control the request's timeout or cancellation.
+//   - uid: The player's UID, which must be a 9 or 10-digit string (see IsValidUID).
+//
+// Returns:
+//   - *models.Owner: A pointer to the owner's Enka profile if the account is linked.
+//   - error: An error if the request fails.
+//
+// Possible errors include:
+//   - ErrNoOwner: If the account is not linked to a public Enka profile.
+//   - Any error returned by GetProfile.
+//
+// Example:
+//
+//	ctx := context.Background()
+//	owner, err := client.GetOwner(ctx, "1301806568")
+//	if err == ErrNoOwner {
+//	    fmt.Println("Account is not linked to Enka")
+//	    return
+//	}
+//	fmt.Println("Owner:", owner.Username)
+func (c *Client) GetOwner(ctx context.Context, uid string) (*models.Owner, error) {
+	profile, err := c.GetProfile(ctx, uid)
+	if err != nil {
+		return nil, err
 	}
-	return true
+
+	if profile.Owner == nil {
+		return nil, ErrNoOwner
+	}
+
+	return profile.Owner, nil
 }
@@ -0,0 +1,55 @@
+package zzz
+
+import "sort"
+
+// SubstatRolls returns the number of times each substat of the Drive Disc was rolled,
+// keyed by property ID. It is taken from the PropertyLevel of RandomPropertyList, which
+// includes the roll that added the substat to the disc.
+func (e *Equipment) SubstatRolls() map[int]int {
+	rolls := make(map[int]int, len(e.RandomPropertyList))
+	for _, prop := range e.RandomPropertyList {
+		rolls[prop.PropertyID] += prop.PropertyLevel
+	}
+	return rolls
+}
+
+// MainStat returns the main stat of the Drive Disc, the single entry of
+// MainPropertyList. It returns false if the disc has no main stat.
+func (e *Equipment) MainStat() (Property, bool) {
+	if len(e.MainPropertyList) == 0 {
+		return Property{}, false
+	}
+	return e.MainPropertyList[0], true
+}
+
+// AvatarStats returns the stats granted to the agent by its Drive Discs, main stats and
+// substats summed by property ID and sorted by ID. The API only provides property IDs
+// (see https://github.com/EnkaNetwork/API-docs/blob/master/docs/zzz/api.md#property-id),
+// so the Name of every entry is empty. Values are sums of the raw PropertyValue of the
+// API, which is a base value: the final value of a main stat also depends on the level
+// of the disc, and that of a substat on its number of rolls (see SubstatRolls).
+func (a *AvatarData) AvatarStats() []StatEntry {
+	totals := make(map[int]float64)
+	for _, item := range a.EquippedList {
+		if item.Equipment == nil {
+			continue
+		}
+		for _, prop := range item.Equipment.MainPropertyList {
+			totals[prop.PropertyID] += float64(prop.PropertyValue)
+		}
+		for _, prop := range item.Equipment.RandomPropertyList {
+			totals[prop.PropertyID] += float64(prop.PropertyValue)
+		}
+	}
+
+	stats := make([]StatEntry, 0, len(totals))
+	for id, value := range totals {
+		stats = append(stats, StatEntry{ID: id, Value: value})
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].ID < stats[j].ID
+	})
+
+	return stats
+}
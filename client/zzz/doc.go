@@ -13,7 +13,7 @@
 // To start using the package, create a new client instance and make API calls:
 //
 //	// Create a new client with default settings
-//	client := zzz.NewClient(nil, nil, "my-app/1.0")
+//	client := zzz.New(zzz.WithUserAgent("my-app/1.0"))
 //
 //	// Fetch a player's profile
 //	profile, err := client.GetProfile(context.Background(), "1504687050")
@@ -31,6 +31,30 @@
 // made to the EnkaNetwork API. You can provide any implementation of the core.Cache interface
 // when creating a new client.
 //
+// The cache package provides a ready-to-use in-memory implementation bounded by the
+// number of entries:
+//
+//	c := cache.NewLRU(1000)
+//	defer c.Close()
+//	client := zzz.New(zzz.WithCache(c))
+//
+// Concurrent requests for the same resource are coalesced: while a request is in flight,
+// other callers asking for the same data wait for it and share its result instead of
+// sending their own request.
+//
+// To get fresh data for a single request, for example after a user updated their
+// showcase, use WithBypassCache. The cached response is skipped, but the new one is
+// still written to the cache:
+//
+//	profile, err := client.GetProfile(zzz.WithBypassCache(ctx), uid)
+//
+// To keep serving data while the API is unavailable, use WithServeStaleOnError with a
+// cache that retains expired entries, such as cache.LRU after SetStaleRetention. Failed
+// requests then return the last cached value along with ErrStaleData.
+//
+// Lookups of UIDs without a player are not cached by default. Use WithNotFoundTTL to
+// cache ErrPlayerNotFound for a short time when the same UIDs are looked up repeatedly.
+//
 // # Rate Limiting
 //
 // The package includes built-in retry logic for handling rate limits (HTTP 429 responses).
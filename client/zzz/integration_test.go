@@ -13,7 +13,6 @@ import (
 	"io"
 	"net/http"
 	"os"
-	"reflect"
 	"testing"
 
 	"github.com/kirinyoku/enkanetwork-go/internal/core"
@@ -79,7 +78,7 @@ func TestGetProfile(t *testing.T) {
 	apiJSON = core.RemoveTTLField(apiJSON)
 	clientJSON = core.RemoveTTLField(clientJSON)
 
-	if !reflect.DeepEqual(apiJSON, clientJSON) {
-		t.Errorf("JSON responses do not match. API JSON: %s\nClient JSON: %s", apiJSON, clientJSON)
+	if missing := core.DiffJSONKeys(apiJSON, clientJSON); len(missing) > 0 {
+		t.Errorf("API response contains fields missing from client structs: %v", missing)
 	}
 }
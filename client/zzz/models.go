@@ -1,6 +1,11 @@
 package zzz
 
-import "github.com/kirinyoku/enkanetwork-go/models"
+import (
+	"encoding/json"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/jsonutil"
+	"github.com/kirinyoku/enkanetwork-go/models"
+)
 
 // ------------------------------- IMPORTANT --------------------------------------
 // For detailed information on properties, refer to the EnkaNetwork API — Zenless
@@ -27,15 +32,46 @@ type Profile struct {
 	UID string `json:"uid,omitempty"`
 	// Region is the player's server region (e.g., "Asia", "Europe", "America").
 	Region string `json:"region,omitempty"`
+	// Extra contains the top-level fields of the API response that are not mapped to
+	// any field of this struct. It allows reading newly added properties before the
+	// library is updated to support them
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Profile. It decodes
+// the known fields of a profile as usual and stores the other top-level fields in Extra.
+func (p *Profile) UnmarshalJSON(data []byte) error {
+	type profile Profile
+
+	var aux profile
+	extra, err := jsonutil.UnmarshalExtra(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	*p = Profile(aux)
+	p.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for Profile. The fields in Extra are
+// encoded along with the others, so they survive a round trip.
+func (p Profile) MarshalJSON() ([]byte, error) {
+	type profile Profile
+	return jsonutil.MarshalExtra(profile(p), p.Extra)
 }
 
+// hoyoType is the hoyo_type value of builds and hoyos belonging to Zenless Zone Zero accounts.
+const hoyoType = 2
+
 // Build contains information about a specific character build in Zenless Zone Zero.
 type Build struct {
-	ID         int         `json:"id"`          // ID of the build
-	Name       string      `json:"name"`        // Name of the build
-	AvatarID   string      `json:"avatar_id"`   // ID of the agent
-	AvatarData *AvatarData `json:"avatar_data"` // Agent data (*zzz.AvatarData)
-	Order      int         `json:"order"`       // Order of the saved build on the Enka
+	ID         int         `json:"id"`           // ID of the build
+	Name       string      `json:"name"`         // Name of the build
+	AvatarID   string      `json:"avatar_id"`    // ID of the agent
+	AvatarData *AvatarData `json:"avatar_data"`  // Agent data (*zzz.AvatarData)
+	Order      int         `json:"order,string"` // Order of the saved build on the Enka, sent as a string by the API
 	// If a build has a live: true field, it indicates it is not a saved build but one
 	// retrieved from the game’s showcase when the "refresh" button is clicked. During
 	// an update, all old live builds are deleted, and new ones are created. Updates
@@ -68,9 +104,9 @@ type AvatarData struct {
 	TalentLevel          int            `json:"TalentLevel"`          // Agent mindscape level
 	SkinID               int            `json:"SkinId"`               // Agent skin ID
 	CoreSkillEnhancement int            `json:"CoreSkillEnhancement"` // Core skill unlocked enhancements (A, B, C, D, E, F)
-	TalentToggleList     []bool         `json:"TalentToggleList"`     // Mindscape Cinema visual toggles
+	TalentToggleList     []bool         `json:"TalentToggleList"`     // Mindscape Cinema visual toggles, by node index (see ActiveCinemaToggles)
 	WeaponEffectState    int            `json:"WeaponEffectState"`    // W-Engine signature special effect state (0: None, 1: OFF, 2: ON)
-	ClaimedRewardList    []int          `json:"ClaimedRewardList"`    // Agent promotion rewards
+	ClaimedRewardList    []int          `json:"ClaimedRewardList"`    // Promotion levels whose rewards were claimed (see HasClaimedPromotionReward)
 	ObtainmentTimestamp  int64          `json:"ObtainmentTimestamp"`  // Agent obtainment timestamp
 	Weapon               *Weapon        `json:"Weapon"`               // Equipped W-Engine
 	SkillLevelList       []SkillLevel   `json:"SkillLevelList"`       // List of agent skill levels (see definitions: https://github.com/EnkaNetwork/API-docs/blob/master/docs/zzz/api.md#skills for indexes)
@@ -143,6 +179,16 @@ type Medal struct {
 	MedalScore int `json:"MedalScore"` // Badge score
 }
 
+// TotalMedalScore returns the sum of the scores of the badges in MedalList, or 0 if the
+// player displays no badges.
+func (s *SocialDetail) TotalMedalScore() int {
+	total := 0
+	for _, medal := range s.MedalList {
+		total += medal.MedalScore
+	}
+	return total
+}
+
 // ProfileDetail contains detailed player profile information.
 type ProfileDetail struct {
 	UID           int64      `json:"Uid"`           // Player UID
@@ -158,18 +204,43 @@ type ProfileDetail struct {
 
 // TitleInfo contains title-related information.
 type TitleInfo struct {
-	// I couldn't find any information about these fields in the Enka API documentation.
-	// If you have any information, please let me know.
-	Title     int   `json:"Title"`     // ??
-	FullTitle int   `json:"FullTitle"` // ??
-	Args      []any `json:"Args"`      // ??
+	Title     int `json:"Title"`     // Title ID
+	FullTitle int `json:"FullTitle"` // ID of the full title, including its prefix
+	// Extra contains all other fields of the title information. Their names are not
+	// stable: the API has exposed them under obfuscated keys that change between game
+	// versions, so they are kept undecoded rather than mapped to struct fields that
+	// would silently stop being filled after a game update
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for TitleInfo. It decodes
+// Title and FullTitle and keeps the obfuscated fields in Extra.
+func (t *TitleInfo) UnmarshalJSON(data []byte) error {
+	type titleInfo TitleInfo
+
+	var aux titleInfo
+	extra, err := jsonutil.UnmarshalExtra(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	*t = TitleInfo(aux)
+	t.Extra = extra
+
+	return nil
 }
 
-// Settings represents build-specific configuration options.
-type Settings struct {
-	AdaptiveColor *bool    `json:"adaptiveColor,omitempty"` // Whether adaptive color is enabled
-	ArtSource     *string  `json:"artSource,omitempty"`     // Source of the image
-	Caption       *string  `json:"caption,omitempty"`       // Caption of the build
-	HonkardWidth  *float64 `json:"honkardWidth,omitempty"`  // Width of the image
-	Transform     *string  `json:"transform,omitempty"`     // Transformation applied to the image
+// MarshalJSON implements the json.Marshaler interface for TitleInfo. The fields in Extra are
+// encoded along with the others, so they survive a round trip.
+func (t TitleInfo) MarshalJSON() ([]byte, error) {
+	type titleInfo TitleInfo
+	return jsonutil.MarshalExtra(titleInfo(t), t.Extra)
 }
+
+// Settings represents the display settings of a build. It is the same type in all the
+// game packages.
+type Settings = models.BuildSettings
+
+// StatEntry is a single stat of a character (see AvatarStats). It is the same type in all
+// the game packages.
+type StatEntry = models.StatEntry
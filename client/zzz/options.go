@@ -0,0 +1,44 @@
+package zzz
+
+//go:generate go run ../../internal/cmd/genoptions
+
+import "github.com/kirinyoku/enkanetwork-go/internal/core"
+
+// Version is the version of the library, e.g. for logging which version an application
+// runs. It is part of DefaultUserAgent.
+const Version = core.Version
+
+// DefaultUserAgent is the User-Agent sent when none is provided with WithUserAgent:
+// "enkanetwork-go/" followed by Version.
+const DefaultUserAgent = core.DefaultUserAgent
+
+// Option configures a Client created with New.
+type Option = core.Option
+
+// HTTPDoer sends HTTP requests. *http.Client implements it. See WithHTTPDoer.
+type HTTPDoer = core.HTTPDoer
+
+// HTTPDoerFunc adapts a function to the HTTPDoer interface.
+type HTTPDoerFunc = core.HTTPDoerFunc
+
+// Cache stores API responses. Any implementation can be passed to WithCache, such as
+// the LRU cache from the cache package.
+type Cache = core.Cache
+
+// RetryConfig controls how requests failing with a transient error are retried.
+type RetryConfig = core.RetryConfig
+
+// RateLimiter limits the rate of requests sent by a Client. *rate.Limiter from
+// golang.org/x/time/rate implements this interface.
+type RateLimiter = core.RateLimiter
+
+// RetryBudget bounds the number of retries across all requests that share it. See
+// WithRetryBudget.
+type RetryBudget = core.RetryBudget
+
+// StaleCache is a Cache that keeps entries after they expire, as required by
+// WithServeStaleOnError. cache.LRU implements it.
+type StaleCache = core.StaleCache
+
+// Clock reads the current time and waits between retries. See WithClock.
+type Clock = core.Clock
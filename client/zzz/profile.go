@@ -0,0 +1,57 @@
+package zzz
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/kirinyoku/enkanetwork-go/models"
+)
+
+// DecodeProfile decodes a profile from the JSON body of an API response. It performs
+// the same decoding as GetProfile, including filling Profile.Extra, and is meant for
+// applications that obtain the response themselves, e.g. through their own proxy, and
+// only need the models of this package.
+//
+// Example:
+//
+//	profile, err := zzz.DecodeProfile(body)
+//	if err != nil {
+//	    fmt.Println("Error:", err)
+//	    return
+//	}
+func DecodeProfile(data []byte) (*Profile, error) {
+	var profile Profile
+	if err := json.Unmarshal(data, &profile); err != nil {
+		return nil, fmt.Errorf("failed to decode profile: %w", err)
+	}
+
+	return &profile, nil
+}
+
+// ShowcasedAvatarIDs returns the IDs of the agents in the player's showcase, in
+// showcase order, as listed in PlayerInfo.ShowcaseDetail.AvatarList. It returns an
+// empty slice if the profile has no showcase details.
+func (p *Profile) ShowcasedAvatarIDs() []int {
+	if p.PlayerInfo.ShowcaseDetail == nil {
+		return []int{}
+	}
+
+	ids := make([]int, 0, len(p.PlayerInfo.ShowcaseDetail.AvatarList))
+	for _, avatar := range p.PlayerInfo.ShowcaseDetail.AvatarList {
+		ids = append(ids, avatar.ID)
+	}
+	return ids
+}
+
+// ShowcaseHidden reports whether the player has hidden the agents in their showcase.
+// Zenless Zone Zero profiles have no privacy flag, so this is a heuristic: the showcase
+// is considered hidden if PlayerInfo.ShowcaseDetail is absent. An empty AvatarList is
+// treated as a showcase with no agents rather than a hidden one.
+func (p *Profile) ShowcaseHidden() bool {
+	return p.PlayerInfo.ShowcaseDetail == nil
+}
+
+// Platform returns the platform of the account, translated from PlatformType.
+func (p *ProfileDetail) Platform() models.Platform {
+	return models.PlatformFromType(p.PlatformType)
+}
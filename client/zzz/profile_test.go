@@ -0,0 +1,91 @@
+package zzz
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+	"github.com/kirinyoku/enkanetwork-go/models"
+)
+
+// TestDecodeProfile checks that a captured API response decodes into the expected values.
+func TestDecodeProfile(t *testing.T) {
+	data, err := os.ReadFile("testdata/profile.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	profile, err := DecodeProfile(data)
+	if err != nil {
+		t.Fatalf("failed to decode profile: %v", err)
+	}
+
+	agent := profile.PlayerInfo.ShowcaseDetail.AvatarList[0]
+
+	tests := []struct {
+		name string
+		got  any
+		want any
+	}{
+		{"nickname", profile.PlayerInfo.SocialDetail.ProfileDetail.Nickname, "Proxy"},
+		{"uid", profile.PlayerInfo.SocialDetail.ProfileDetail.UID, int64(1500438496)},
+		{"platform", profile.PlayerInfo.SocialDetail.ProfileDetail.Platform(), models.PlatformPC},
+		{"showcased avatars", profile.ShowcasedAvatarIDs(), []int{1191}},
+		{"showcase hidden", profile.ShowcaseHidden(), false},
+		{"agent level", agent.Level, 60},
+		{"core skill", agent.CoreSkillLetter(), "F"},
+		{"disc main stat", agent.EquippedList[0].Equipment.MainPropertyList[0].PropertyID, 11103},
+		{"disc set", DiscSetID(agent.EquippedList[0].Equipment.ID), 31400},
+		{"disc substat rolls", agent.EquippedList[0].Equipment.SubstatRolls(), map[int]int{20103: 3, 21103: 2}},
+		{"stats", agent.AvatarStats(), []StatEntry{{ID: 11103, Value: 550}, {ID: 20103, Value: 48}, {ID: 21103, Value: 96}}},
+		{"w-engine phase", agent.Weapon.Phase(), 1},
+		{"signature effect", agent.SignatureEffectActive(), true},
+		{"ttl", profile.TTL, 120},
+		{"extra", len(profile.Extra), 0},
+	}
+
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if main, ok := agent.EquippedList[0].Equipment.MainStat(); !ok || main.PropertyValue != 550 {
+		t.Errorf("MainStat() = %+v, %v, want PropertyValue 550, true", main, ok)
+	}
+
+	encoded, err := json.Marshal(profile)
+	if err != nil {
+		t.Fatalf("failed to encode profile: %v", err)
+	}
+	if missing := core.DiffJSONKeys(data, encoded); len(missing) > 0 {
+		t.Errorf("fixture contains fields missing from the structs: %v", missing)
+	}
+	if got, want := core.NormalizeJSON(encoded), core.NormalizeJSON(data); !bytes.Equal(got, want) {
+		t.Errorf("re-encoded profile differs from the fixture:\n got %s\nwant %s", got, want)
+	}
+}
+
+// TestTitleInfoRoundTrip checks that unknown title fields are preserved.
+func TestTitleInfoRoundTrip(t *testing.T) {
+	data := []byte(`{"Args":[1],"FullTitle":2,"HFKHLLBMPHM":3,"Title":4}`)
+
+	var info TitleInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		t.Fatalf("failed to decode title info: %v", err)
+	}
+	if info.Title != 4 || info.FullTitle != 2 || len(info.Extra) != 2 {
+		t.Errorf("unexpected title info: %+v", info)
+	}
+
+	encoded, err := json.Marshal(info)
+	if err != nil {
+		t.Fatalf("failed to encode title info: %v", err)
+	}
+	if string(encoded) != string(data) {
+		t.Errorf("expected %s, got %s", data, encoded)
+	}
+}
@@ -0,0 +1,67 @@
+package zzz
+
+import "sort"
+
+// SetBonus describes a Drive Disc set equipped on an agent with enough pieces to
+// activate at least its 2-piece bonus.
+type SetBonus struct {
+	SetID int // Drive Disc set (suit) ID, see DiscSetID
+	Count int // Number of equipped Drive Discs belonging to the set
+}
+
+// TwoPiece reports whether the 2-piece bonus of the set is active.
+func (b SetBonus) TwoPiece() bool {
+	return b.Count >= 2
+}
+
+// FourPiece reports whether the 4-piece bonus of the set is active.
+func (b SetBonus) FourPiece() bool {
+	return b.Count >= 4
+}
+
+// DiscSetID returns the set (suit) ID of a Drive Disc from its item ID.
+//
+// A Drive Disc ID is a 5-digit number where the first three digits identify the set,
+// the fourth digit the rarity and the last digit the slot, e.g. 31443 is a disc of
+// set 31400 with rarity 4 (S) in slot 3. The set ID is therefore obtained by zeroing
+// the last two digits: floor(id / 100) * 100.
+func DiscSetID(discID int) int {
+	return discID / 100 * 100
+}
+
+// DiscSetCounts groups the Drive Discs equipped on the agent by their set ID
+// (see DiscSetID) and returns the number of discs of each set.
+func (a *AvatarData) DiscSetCounts() map[int]int {
+	counts := make(map[int]int)
+	for _, item := range a.EquippedList {
+		if item.Equipment == nil {
+			continue
+		}
+		counts[DiscSetID(item.Equipment.ID)]++
+	}
+	return counts
+}
+
+// ActiveSetBonuses returns the Drive Disc sets with at least 2 equipped pieces, i.e.
+// the sets whose 2-piece (and possibly 4-piece) bonus is active. The result is
+// sorted by set ID.
+//
+// Example:
+//
+//	for _, bonus := range agent.ActiveSetBonuses() {
+//	    fmt.Println(bonus.SetID, bonus.TwoPiece(), bonus.FourPiece())
+//	}
+func (a *AvatarData) ActiveSetBonuses() []SetBonus {
+	var bonuses []SetBonus
+	for setID, count := range a.DiscSetCounts() {
+		if count >= 2 {
+			bonuses = append(bonuses, SetBonus{SetID: setID, Count: count})
+		}
+	}
+
+	sort.Slice(bonuses, func(i, j int) bool {
+		return bonuses[i].SetID < bonuses[j].SetID
+	})
+
+	return bonuses
+}
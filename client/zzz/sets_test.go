@@ -0,0 +1,40 @@
+package zzz
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDiscSetID checks that the set ID is derived by zeroing the last two digits of the disc ID.
+func TestDiscSetID(t *testing.T) {
+	tests := map[int]int{
+		31443: 31400,
+		31021: 31000,
+		32715: 32700,
+	}
+
+	for discID, want := range tests {
+		if got := DiscSetID(discID); got != want {
+			t.Errorf("DiscSetID(%d) = %d, want %d", discID, got, want)
+		}
+	}
+}
+
+// TestActiveSetBonuses checks that only sets with at least two equipped discs are reported.
+func TestActiveSetBonuses(t *testing.T) {
+	agent := &AvatarData{
+		EquippedList: []EquippedItem{
+			{Slot: 1, Equipment: &Equipment{ID: 31441}},
+			{Slot: 2, Equipment: &Equipment{ID: 31442}},
+			{Slot: 3, Equipment: &Equipment{ID: 31443}},
+			{Slot: 4, Equipment: &Equipment{ID: 31444}},
+			{Slot: 5, Equipment: &Equipment{ID: 32745}},
+			{Slot: 6, Equipment: &Equipment{ID: 32846}},
+		},
+	}
+
+	want := []SetBonus{{SetID: 31400, Count: 4}}
+	if got := agent.ActiveSetBonuses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ActiveSetBonuses() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,114 @@
+package zzz
+
+// SkillType identifies an agent skill by its index in AvatarData.SkillLevelList
+// (see https://github.com/EnkaNetwork/API-docs/blob/master/docs/zzz/api.md#skills).
+type SkillType int
+
+const (
+	SkillBasicAttack   SkillType = 0 // Basic Attack
+	SkillSpecialAttack SkillType = 1 // Special Attack
+	SkillDodge         SkillType = 2 // Dodge (Dash)
+	SkillChainAttack   SkillType = 3 // Chain Attack (Ultimate)
+	SkillCore          SkillType = 5 // Core Skill
+	SkillAssist        SkillType = 6 // Assist
+)
+
+// String returns the in-game name of the skill type.
+func (t SkillType) String() string {
+	switch t {
+	case SkillBasicAttack:
+		return "Basic Attack"
+	case SkillSpecialAttack:
+		return "Special Attack"
+	case SkillDodge:
+		return "Dodge"
+	case SkillChainAttack:
+		return "Chain Attack"
+	case SkillCore:
+		return "Core Skill"
+	case SkillAssist:
+		return "Assist"
+	default:
+		return "Unknown"
+	}
+}
+
+// SkillLevel returns the level of the agent's skill of the given type. The second
+// return value is false if SkillLevelList has no entry for the skill.
+//
+// Example:
+//
+//	if level, ok := agent.SkillLevel(zzz.SkillChainAttack); ok {
+//	    fmt.Println("Chain Attack level:", level)
+//	}
+func (a *AvatarData) SkillLevel(t SkillType) (int, bool) {
+	for _, skill := range a.SkillLevelList {
+		if skill.Index == int(t) {
+			return skill.Level, true
+		}
+	}
+	return 0, false
+}
+
+// CoreSkillLetter translates CoreSkillEnhancement into the letter notation used by the
+// game UI: 1 through 6 unlocked enhancements correspond to "A" through "F". It returns
+// an empty string if no enhancement is unlocked or the value is out of range.
+func (a *AvatarData) CoreSkillLetter() string {
+	if a.CoreSkillEnhancement < 1 || a.CoreSkillEnhancement > 6 {
+		return ""
+	}
+	return string(rune('A' + a.CoreSkillEnhancement - 1))
+}
+
+// Skill level caps used by IsMaxed. They are game constants, exposed as variables so
+// they can be raised without waiting for a new version of the library if the game
+// raises its caps.
+var (
+	// MaxSkillLevel is the highest level of the Basic Attack, Special Attack, Dodge,
+	// Chain Attack and Assist skills that can be reached by upgrading them, not counting
+	// the bonus levels granted by Mindscape Cinema 3 and 5.
+	MaxSkillLevel = 12
+	// MaxCoreSkillLevel is the highest level of the Core Skill, reached when every
+	// enhancement is unlocked.
+	MaxCoreSkillLevel = 7
+	// MaxCoreSkillEnhancement is the number of Core Skill enhancements (A through F).
+	MaxCoreSkillEnhancement = 6
+)
+
+// upgradableSkills lists the skills capped by MaxSkillLevel.
+var upgradableSkills = []SkillType{
+	SkillBasicAttack,
+	SkillSpecialAttack,
+	SkillDodge,
+	SkillChainAttack,
+	SkillAssist,
+}
+
+// TotalSkillLevels returns the sum of the levels of all skills in SkillLevelList,
+// including the Core Skill.
+func (a *AvatarData) TotalSkillLevels() int {
+	total := 0
+	for _, skill := range a.SkillLevelList {
+		total += skill.Level
+	}
+	return total
+}
+
+// IsMaxed reports whether the agent's skills are fully upgraded: every Core Skill
+// enhancement is unlocked (up to F), the Core Skill is at MaxCoreSkillLevel and every
+// other skill is at least at MaxSkillLevel. Levels above the caps, granted by Mindscape
+// Cinema, also count as maxed. An agent whose SkillLevelList lacks a skill is not maxed.
+func (a *AvatarData) IsMaxed() bool {
+	if a.CoreSkillEnhancement < MaxCoreSkillEnhancement {
+		return false
+	}
+	if level, ok := a.SkillLevel(SkillCore); !ok || level < MaxCoreSkillLevel {
+		return false
+	}
+	for _, skill := range upgradableSkills {
+		if level, ok := a.SkillLevel(skill); !ok || level < MaxSkillLevel {
+			return false
+		}
+	}
+	return true
+}
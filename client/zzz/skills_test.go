@@ -0,0 +1,84 @@
+package zzz
+
+import "testing"
+
+// TestSkillLevel checks that skill levels are looked up by their index.
+func TestSkillLevel(t *testing.T) {
+	agent := &AvatarData{
+		SkillLevelList: []SkillLevel{
+			{Index: 0, Level: 12},
+			{Index: 5, Level: 7},
+			{Index: 6, Level: 9},
+		},
+	}
+
+	tests := []struct {
+		skill     SkillType
+		wantLevel int
+		wantOK    bool
+	}{
+		{SkillBasicAttack, 12, true},
+		{SkillCore, 7, true},
+		{SkillAssist, 9, true},
+		{SkillChainAttack, 0, false},
+	}
+
+	for _, tt := range tests {
+		level, ok := agent.SkillLevel(tt.skill)
+		if level != tt.wantLevel || ok != tt.wantOK {
+			t.Errorf("SkillLevel(%v) = %d, %v, want %d, %v", tt.skill, level, ok, tt.wantLevel, tt.wantOK)
+		}
+	}
+}
+
+// TestCoreSkillLetter checks the translation of CoreSkillEnhancement to letters.
+func TestCoreSkillLetter(t *testing.T) {
+	tests := map[int]string{
+		-1: "",
+		0:  "",
+		1:  "A",
+		3:  "C",
+		6:  "F",
+		7:  "",
+	}
+
+	for enhancement, want := range tests {
+		agent := &AvatarData{CoreSkillEnhancement: enhancement}
+		if got := agent.CoreSkillLetter(); got != want {
+			t.Errorf("CoreSkillLetter() with enhancement %d = %q, want %q", enhancement, got, want)
+		}
+	}
+}
+
+// TestSkillInvestment checks the sum of skill levels and the detection of fully upgraded agents.
+func TestSkillInvestment(t *testing.T) {
+	maxed := []SkillLevel{
+		{Index: 0, Level: 12},
+		{Index: 1, Level: 14},
+		{Index: 2, Level: 12},
+		{Index: 3, Level: 16},
+		{Index: 5, Level: 7},
+		{Index: 6, Level: 12},
+	}
+
+	tests := []struct {
+		name      string
+		agent     *AvatarData
+		wantTotal int
+		wantMaxed bool
+	}{
+		{"maxed", &AvatarData{CoreSkillEnhancement: 6, SkillLevelList: maxed}, 73, true},
+		{"core skill not unlocked", &AvatarData{CoreSkillEnhancement: 5, SkillLevelList: maxed}, 73, false},
+		{"missing skills", &AvatarData{CoreSkillEnhancement: 6, SkillLevelList: maxed[:3]}, 38, false},
+		{"no skills", &AvatarData{}, 0, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.agent.TotalSkillLevels(); got != tt.wantTotal {
+			t.Errorf("%s: TotalSkillLevels() = %d, want %d", tt.name, got, tt.wantTotal)
+		}
+		if got := tt.agent.IsMaxed(); got != tt.wantMaxed {
+			t.Errorf("%s: IsMaxed() = %v, want %v", tt.name, got, tt.wantMaxed)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package zzz
+
+// IsValidUID reports whether uid is a structurally valid Zenless Zone Zero UID.
+//
+// Unlike Genshin Impact and Honkai: Star Rail UIDs, which always have 9 digits,
+// Zenless Zone Zero UIDs have 10 digits on the overseas servers, where the leading
+// digits identify the region (e.g., "10" for America, "13" for Europe), and 9 digits on
+// the mainland China servers. A valid UID therefore:
+//   - Is 9 or 10 characters long.
+//   - Consists only of the digits 0-9.
+//   - Does not start with 0, which also rules out a UID of all zeros.
+//
+// The client methods taking a UID use this function to return ErrInvalidUIDFormat
+// without making a request for input that can never match an existing account.
+func IsValidUID(uid string) bool {
+	if len(uid) != 9 && len(uid) != 10 {
+		return false
+	}
+	if uid[0] == '0' {
+		return false
+	}
+	for _, r := range uid {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package zzz
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/testutil"
+)
+
+// TestIsValidUID checks IsValidUID against UIDs of both lengths and impossible ones.
+func TestIsValidUID(t *testing.T) {
+	tests := []struct {
+		uid  string
+		want bool
+	}{
+		{"1301806568", true},
+		{"150438496", true},
+		{"", false},
+		{"13018065", false},
+		{"13018065680", false},
+		{"0000000000", false},
+		{"000000000", false},
+		{"0301806568", false},
+		{"13018O6568", false},
+		{"-30180656", false},
+		{" 130180656", false},
+	}
+
+	for _, tt := range tests {
+		if got := IsValidUID(tt.uid); got != tt.want {
+			t.Errorf("IsValidUID(%q) = %v, want %v", tt.uid, got, tt.want)
+		}
+	}
+}
+
+// TestGetProfileByID checks that integer UIDs are validated and requested like string UIDs.
+func TestGetProfileByID(t *testing.T) {
+	var path string
+	doer := HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		path = req.URL.Path
+		return testutil.Response(req, http.StatusNotFound, ""), nil
+	})
+	client := New(WithHTTPDoer(doer), WithNoRetry())
+
+	tests := []struct {
+		uid  int64
+		want error
+		path string
+	}{
+		{2301806568, ErrPlayerNotFound, "/api/zzz/uid/2301806568"},
+		{150438496, ErrPlayerNotFound, "/api/zzz/uid/150438496"},
+		{-130180656, ErrInvalidUIDFormat, ""},
+		{13018065680, ErrInvalidUIDFormat, ""},
+		{0, ErrInvalidUIDFormat, ""},
+	}
+
+	for _, tt := range tests {
+		path = ""
+		if _, err := client.GetProfileByID(context.Background(), tt.uid); !errors.Is(err, tt.want) {
+			t.Errorf("GetProfileByID(%d) error = %v, want %v", tt.uid, err, tt.want)
+		}
+		if path != tt.path {
+			t.Errorf("GetProfileByID(%d) requested %q, want %q", tt.uid, path, tt.path)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package zzz
+
+// Phase returns the W-Engine's phase (1-5), the in-game refinement level of a W-Engine
+// raised by consuming duplicate copies. It is stored in the UpgradeLevel field.
+func (w *Weapon) Phase() int {
+	return w.UpgradeLevel
+}
+
+// ModificationLevel returns the W-Engine's modification level, the in-game ascension
+// level that raises its level cap. It is stored in the BreakLevel field.
+func (w *Weapon) ModificationLevel() int {
+	return w.BreakLevel
+}
+
+// Weapon effect states of AvatarData.WeaponEffectState.
+const (
+	WeaponEffectNone = 0 // The equipped W-Engine has no signature special effect
+	WeaponEffectOff  = 1 // The signature special effect is turned off
+	WeaponEffectOn   = 2 // The signature special effect is turned on
+)
+
+// SignatureEffectActive reports whether the special visual effect of the agent's
+// signature W-Engine is turned on (WeaponEffectState is WeaponEffectOn).
+func (a *AvatarData) SignatureEffectActive() bool {
+	return a.WeaponEffectState == WeaponEffectOn
+}
@@ -0,0 +1,44 @@
+package zzz
+
+import "testing"
+
+// TestWeaponLevels checks that Phase and ModificationLevel read UpgradeLevel and BreakLevel
+// at the lowest and highest values of a W-Engine.
+func TestWeaponLevels(t *testing.T) {
+	tests := []struct {
+		name              string
+		weapon            Weapon
+		phase             int
+		modificationLevel int
+	}{
+		{"unpromoted", Weapon{Level: 10, UpgradeLevel: 1, BreakLevel: 0}, 1, 0},
+		{"first modification", Weapon{Level: 20, UpgradeLevel: 1, BreakLevel: 1}, 1, 1},
+		{"max phase", Weapon{Level: 50, UpgradeLevel: 5, BreakLevel: 4}, 5, 4},
+		{"max level", Weapon{Level: 60, UpgradeLevel: 5, BreakLevel: 5}, 5, 5},
+	}
+
+	for _, tt := range tests {
+		if got := tt.weapon.Phase(); got != tt.phase {
+			t.Errorf("%s: Phase() = %d, want %d", tt.name, got, tt.phase)
+		}
+		if got := tt.weapon.ModificationLevel(); got != tt.modificationLevel {
+			t.Errorf("%s: ModificationLevel() = %d, want %d", tt.name, got, tt.modificationLevel)
+		}
+	}
+}
+
+// TestSignatureEffectActive checks that only WeaponEffectOn is reported as active.
+func TestSignatureEffectActive(t *testing.T) {
+	tests := map[int]bool{
+		WeaponEffectNone: false,
+		WeaponEffectOff:  false,
+		WeaponEffectOn:   true,
+	}
+
+	for state, want := range tests {
+		agent := &AvatarData{WeaponEffectState: state}
+		if got := agent.SignatureEffectActive(); got != want {
+			t.Errorf("SignatureEffectActive() with state %d = %v, want %v", state, got, want)
+		}
+	}
+}
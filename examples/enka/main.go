@@ -6,49 +6,12 @@ import (
 	"fmt"
 	"log"
 	"net/http"
-	"sync"
 	"time"
 
+	"github.com/kirinyoku/enkanetwork-go/cache"
 	"github.com/kirinyoku/enkanetwork-go/client/enka"
 )
 
-// Simple in-memory cache
-type Cache struct {
-	data map[string]cacheEntry
-	mu   sync.RWMutex
-}
-
-type cacheEntry struct {
-	value     any
-	expiresAt time.Time
-}
-
-func NewCache() *Cache {
-	return &Cache{
-		data: make(map[string]cacheEntry),
-	}
-}
-
-func (c *Cache) Get(key string) (any, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	entry, exists := c.data[key]
-	if !exists || time.Now().After(entry.expiresAt) {
-		return nil, false
-	}
-
-	return entry.value, true
-}
-
-func (c *Cache) Set(key string, value any, expiration time.Duration) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.data[key] = cacheEntry{
-		value:     value,
-		expiresAt: time.Now().Add(expiration),
-	}
-}
-
 func main() {
 	// Create a context with a 15-second timeout to prevent hanging indefinitely
 	// This ensures the program won't run forever if the API is unresponsive
@@ -71,11 +34,17 @@ func main() {
 
 	// Initialize an in-memory cache to store API responses
 	// This reduces the number of API calls and improves performance
-	cache := NewCache()
+	// Entries beyond 100 are evicted, least recently used first
+	responseCache := cache.NewLRU(100)
+	defer responseCache.Close()
 
 	// Create a new Enka client with our custom HTTP client and cache
 	// The User-Agent string helps Enka Network identify the source of API requests
-	client := enka.NewClient(httpClient, cache, "enkanetwork-go/1.0")
+	client := enka.New(
+		enka.WithHTTPClient(httpClient),
+		enka.WithCache(responseCache),
+		enka.WithUserAgent("enkanetwork-go/1.0"),
+	)
 
 	// Define the Enka Network username to look up
 	username := "Algoinde"
@@ -99,66 +68,37 @@ func main() {
 	fmt.Printf("Username: %s\n", profile.Username)
 	fmt.Printf("Bio: %s\n", profile.Profile.Bio)
 
-	// Fetch the user's hoyo accounts. A single EnkaNetwork user can have multiple hoyo accounts.
-	// The endpoint will only return accounts that are both verified and public
-	// (users can hide accounts; unverified accounts are hidden by default)
-	hoyos, err := client.GetUserProfileHoyos(ctx, username)
+	// Fetch all of the user's hoyo accounts along with their details and character builds.
+	// A single EnkaNetwork user can have multiple hoyo accounts. Only accounts that are both
+	// verified and public are returned (users can hide accounts; unverified accounts are
+	// hidden by default). Errors for a single account are recorded on it instead of
+	// failing the whole call.
+	account, err := client.GetFullAccount(ctx, username)
 	if err != nil {
-		switch err {
-		case enka.ErrInvalidUsername:
-			log.Fatalf("Invalid username format %q: %v", username, err)
-		case enka.ErrUserNotFound:
-			log.Fatalf("User not found for username %q: %v", username, err)
-		default:
-			log.Fatalf("Unexpected error fetching hoyo accounts: %v", err)
-		}
+		log.Fatalf("Unexpected error fetching hoyo accounts: %v", err)
 	}
 
 	// Iterate through each hoyo account
 	fmt.Printf("\nHoyo Accounts:\n")
-	for hash, hoyo := range hoyos {
+	for _, hoyo := range account.Hoyos {
 		// Display basic account information
-		fmt.Printf("Account Hash: %s, Region: %s, UID: %d\n", hash, hoyo.Region, hoyo.UID)
-
-		// Fetch detailed information for this specific hoyo account
-		h, err := client.GetUserProfileHoyo(ctx, username, hash)
-		if err != nil {
-			switch err {
-			case enka.ErrInvalidUsername:
-				log.Fatalf("Invalid username format %q: %v", username, err)
-			case enka.ErrInvalidHoyoHash:
-				log.Fatalf("Invalid hoyo hash format %q: %v", hash, err)
-			case enka.ErrHoyoAccountNotFound:
-				log.Fatalf("Hoyo account not found for username %q and hash %q: %v", username, hash, err)
-			default:
-				log.Fatalf("Unexpected error fetching hoyo account details: %v", err)
-			}
+		fmt.Printf("Account Hash: %s, Region: %s, UID: %d\n", hoyo.Hash, hoyo.Hoyo.Region, hoyo.Hoyo.UID)
+
+		if hoyo.Err != nil {
+			fmt.Printf("Failed to fetch hoyo account %q: %v\n", hoyo.Hash, hoyo.Err)
+			continue
 		}
 
 		// Display player information from the hoyo account
-		fmt.Printf("Nickname: %s\n", h.PlayerInfo.Nickname)
-		fmt.Printf("World Level: %d\n", h.PlayerInfo.Level)
-
-		// Fetch the character builds for this hoyo account
-		// This includes all saved character builds across different games
-		avatarBuilds, err := client.GetUserProfileHoyoBuilds(ctx, username, hash)
-		if err != nil {
-			switch err {
-			case enka.ErrInvalidUsername:
-				log.Fatalf("Invalid username format %q: %v", username, err)
-			case enka.ErrInvalidHoyoHash:
-				log.Fatalf("Invalid hoyo hash format %q: %v", hash, err)
-			case enka.ErrHoyoAccountBuildsNotFound:
-				log.Fatalf("Hoyo account builds not found for username %q and hash %q: %v", username, hash, err)
-			default:
-				log.Fatalf("Unexpected error fetching character builds: %v", err)
-			}
+		if hoyo.Hoyo.PlayerInfo != nil {
+			fmt.Printf("Nickname: %s\n", hoyo.Hoyo.PlayerInfo.Nickname)
+			fmt.Printf("World Level: %d\n", hoyo.Hoyo.PlayerInfo.Level)
 		}
 
 		// Display all character builds.
 		// Each avatar can have multiple builds
 		fmt.Printf("\nCharacter Builds:\n")
-		for avatarID, builds := range avatarBuilds {
+		for avatarID, builds := range hoyo.Builds {
 			fmt.Println("Builds for character ID:", avatarID)
 			for _, build := range builds {
 				switch build.HoyoType {
@@ -180,8 +120,8 @@ func main() {
 	// The cache is meant for internal use by the client methods only.
 	// This example is shown purely for educational purposes.
 	// -----------------------------------------------------------------------
-	cacheKey := fmt.Sprintf("user_%s", username)
-	data, ok := cache.Get(cacheKey)
+	cacheKey := fmt.Sprintf("enka_user_%s", username)
+	data, ok := responseCache.Get(cacheKey)
 	if !ok {
 		log.Fatalf("Failed to get cached profile: %v", err)
 	}
@@ -3,52 +3,16 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
-	"sync"
 	"time"
 
+	"github.com/kirinyoku/enkanetwork-go/cache"
 	"github.com/kirinyoku/enkanetwork-go/client/genshin"
 )
 
-// Simple in-memory cache
-type Cache struct {
-	data map[string]cacheEntry
-	mu   sync.RWMutex
-}
-
-type cacheEntry struct {
-	value     any
-	expiresAt time.Time
-}
-
-func NewCache() *Cache {
-	return &Cache{
-		data: make(map[string]cacheEntry),
-	}
-}
-
-func (c *Cache) Get(key string) (any, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	entry, exists := c.data[key]
-	if !exists || time.Now().After(entry.expiresAt) {
-		return nil, false
-	}
-
-	return entry.value, true
-}
-
-func (c *Cache) Set(key string, value any, expiration time.Duration) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.data[key] = cacheEntry{
-		value:     value,
-		expiresAt: time.Now().Add(expiration),
-	}
-}
-
 func main() {
 	// Create a context with a 15-second timeout to prevent hanging indefinitely
 	// This ensures the program won't run forever if the API is unresponsive
@@ -71,11 +35,17 @@ func main() {
 
 	// Initialize an in-memory cache to store API responses
 	// This reduces the number of API calls and improves performance
-	cache := NewCache()
+	// Entries beyond 100 are evicted, least recently used first
+	responseCache := cache.NewLRU(100)
+	defer responseCache.Close()
 
 	// Create a new Enka client with our custom HTTP client and cache
 	// The User-Agent string helps Enka Network identify the source of API requests
-	client := genshin.NewClient(httpClient, cache, "enkanetwork-go/1.0")
+	client := genshin.New(
+		genshin.WithHTTPClient(httpClient),
+		genshin.WithCache(responseCache),
+		genshin.WithUserAgent("enkanetwork-go/1.0"),
+	)
 
 	// Define the UID of the player to fetch.
 	const uid = "618285856"
@@ -83,14 +53,17 @@ func main() {
 	// Fetch the full Profile, which includes basic player info and characters showcase.
 	profile, err := client.GetProfile(ctx, uid)
 	if err != nil {
-		switch err {
-		case genshin.ErrInvalidUIDFormat:
+		var apiErr *genshin.APIError
+		switch {
+		case errors.Is(err, genshin.ErrInvalidUIDFormat):
 			log.Fatalf("Invalid UID format %q: %v", uid, err)
-		case genshin.ErrPlayerNotFound:
+		case errors.Is(err, genshin.ErrPlayerNotFound):
 			log.Fatalf("Player not found for UID %q: %v", uid, err)
-		case genshin.ErrRateLimited:
+		case errors.As(err, &apiErr) && errors.Is(err, genshin.ErrRateLimited):
+			log.Fatalf("Rate limit exceeded, retry after %v: %v", apiErr.RetryAfter, err)
+		case errors.Is(err, genshin.ErrRateLimited):
 			log.Fatalf("Rate limit exceeded: %v", err)
-		case genshin.ErrServerMaintenance:
+		case errors.Is(err, genshin.ErrServerMaintenance):
 			log.Fatalf("Server under maintenance: %v", err)
 		default:
 			log.Fatalf("Unexpected error fetching profile: %v", err)
@@ -126,7 +99,7 @@ func main() {
 	// This example is shown purely for educational purposes.
 	// -----------------------------------------------------------------------
 	cacheKey := fmt.Sprintf("genshin_%s", uid)
-	data, ok := cache.Get(cacheKey)
+	data, ok := responseCache.Get(cacheKey)
 	if !ok {
 		log.Fatalf("Failed to get cached profile: %v", err)
 	}
@@ -1,3 +1,5 @@
 module github.com/kirinyoku/enkanetwork-go
 
-go 1.24
+go 1.24.0
+
+require golang.org/x/sync v0.19.0
@@ -0,0 +1,206 @@
+// Genoptions generates the options_gen.go file of a client package, which exports the
+// options and context helpers of internal/core as documented functions. Each function
+// calls its counterpart in internal/core and carries its doc comment, so the options are
+// documented in one place and cannot be reassigned by importers.
+//
+// It is run by go generate in each client package:
+//
+//	//go:generate go run ../../internal/cmd/genoptions
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// export is a function of internal/core exported by the client packages.
+type export struct {
+	name string
+	pkgs []string // Client packages exporting the function, or nil for all of them
+}
+
+// exports lists the exported functions in the order they appear in the generated files.
+var exports = []export{
+	{name: "WithHTTPClient"},
+	{name: "WithTransport"},
+	{name: "WithHTTPDoer"},
+	{name: "WithCache"},
+	{name: "WithUserAgent"},
+	{name: "WithUserAgentSuffix"},
+	{name: "WithRequireUserAgent"},
+	{name: "WithRetryConfig"},
+	{name: "WithNoRetry"},
+	{name: "WithRetryOnMaintenance"},
+	{name: "WithRetryBudget"},
+	{name: "NewRetryBudget"},
+	{name: "WithClock"},
+	{name: "WithRateLimiter"},
+	{name: "WithMaxConcurrency"},
+	{name: "WithRequestTimeout"},
+	{name: "WithFallbackTimeout"},
+	{name: "WithMaxResponseSize"},
+	{name: "WithAPIVersion"},
+	{name: "WithLanguage"},
+	{name: "WithConditionalRequests"},
+	{name: "WithServeStaleOnError"},
+	{name: "WithMinCacheTTL"},
+	{name: "WithMaxCacheTTL"},
+	{name: "WithNotFoundTTL", pkgs: []string{"genshin", "hsr", "zzz"}},
+	{name: "WithCaseInsensitiveUsernames", pkgs: []string{"enka"}},
+	{name: "WithCacheErrorHandler"},
+	{name: "WithHeaders"},
+	{name: "NamespacedCache"},
+	{name: "WithRequestID"},
+	{name: "RequestIDFromContext"},
+	{name: "WithRequestHeaders"},
+	{name: "WithBypassCache"},
+}
+
+func main() {
+	pkg := flag.String("pkg", os.Getenv("GOPACKAGE"), "name of the client package")
+	coreDir := flag.String("core", "../../internal/core", "directory of the internal/core package")
+	out := flag.String("o", "options_gen.go", "output file")
+	flag.Parse()
+
+	src, err := generate(*coreDir, *pkg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// generate returns the source of the options_gen.go file of the client package pkg,
+// reading the declarations of the exported functions from coreDir.
+func generate(coreDir, pkg string) ([]byte, error) {
+	if pkg == "" {
+		return nil, fmt.Errorf("genoptions: no package name; run it with go generate or set -pkg")
+	}
+
+	fset := token.NewFileSet()
+	entries, err := os.ReadDir(coreDir)
+	if err != nil {
+		return nil, err
+	}
+	decls := make(map[string]*ast.FuncDecl)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, coreDir+"/"+name, nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+		for _, decl := range file.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil {
+				decls[fn.Name.Name] = fn
+			}
+		}
+	}
+
+	var funcs bytes.Buffer
+	for _, e := range exports {
+		if e.pkgs != nil && !slices.Contains(e.pkgs, pkg) {
+			continue
+		}
+		fn, ok := decls[e.name]
+		if !ok {
+			return nil, fmt.Errorf("genoptions: %s not found in %s", e.name, coreDir)
+		}
+		if err := writeFunc(&funcs, fset, fn, pkg); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by genoptions; DO NOT EDIT.\n\npackage %s\n\nimport (\n", pkg)
+	for _, imp := range []struct{ prefix, path string }{
+		{"context.", "context"},
+		{"http.", "net/http"},
+		{"time.", "time"},
+	} {
+		if bytes.Contains(funcs.Bytes(), []byte(imp.prefix)) {
+			fmt.Fprintf(&buf, "\t%q\n", imp.path)
+		}
+	}
+	buf.WriteString("\n\t\"github.com/kirinyoku/enkanetwork-go/internal/core\"\n)\n")
+	buf.Write(funcs.Bytes())
+
+	return format.Source(buf.Bytes())
+}
+
+// exampleQualifier matches the package qualifier of the identifiers used in the examples
+// of internal/core, which are written for the genshin package.
+var exampleQualifier = regexp.MustCompile(`\bgenshin\.([A-Z])`)
+
+// clientQualifiers lists the qualifiers of the client packages, to recognize examples
+// that use several of them on purpose.
+var clientQualifiers = []string{"hsr.", "zzz.", "enka."}
+
+// qualify rewrites the examples of doc, written for the genshin package, for the client
+// package pkg. Docs whose examples combine several client packages, such as a retry
+// budget shared by a genshin and an hsr client, are kept as they are.
+func qualify(doc, pkg string) string {
+	for _, qualifier := range clientQualifiers {
+		if strings.Contains(doc, qualifier) {
+			return doc
+		}
+	}
+	return exampleQualifier.ReplaceAllString(doc, pkg+".$1")
+}
+
+// writeFunc writes a function calling fn, with the doc comment and signature of fn. The
+// examples of the doc comment are rewritten for the client package pkg.
+func writeFunc(buf *bytes.Buffer, fset *token.FileSet, fn *ast.FuncDecl, pkg string) error {
+	buf.WriteString("\n")
+	if fn.Doc != nil {
+		doc := strings.TrimRight(fn.Doc.Text(), "\n")
+		// The sentinel errors are exported by the client packages themselves
+		doc = strings.ReplaceAll(doc, "errors.Err", "Err")
+		doc = qualify(doc, pkg)
+		for _, line := range strings.Split(doc, "\n") {
+			switch {
+			case line == "":
+				buf.WriteString("//\n")
+			case strings.HasPrefix(line, "\t"):
+				buf.WriteString("//" + line + "\n")
+			default:
+				buf.WriteString("// " + line + "\n")
+			}
+		}
+	}
+
+	var sig bytes.Buffer
+	if err := printer.Fprint(&sig, fset, fn.Type); err != nil {
+		return err
+	}
+
+	var args []string
+	for _, field := range fn.Type.Params.List {
+		_, variadic := field.Type.(*ast.Ellipsis)
+		for _, name := range field.Names {
+			if variadic {
+				args = append(args, name.Name+"...")
+			} else {
+				args = append(args, name.Name)
+			}
+		}
+	}
+
+	fmt.Fprintf(buf, "%s {\n\treturn core.%s(%s)\n}\n",
+		strings.Replace(sig.String(), "func", "func "+fn.Name.Name, 1), fn.Name.Name, strings.Join(args, ", "))
+	return nil
+}
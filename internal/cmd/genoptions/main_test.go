@@ -0,0 +1,46 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+// TestGeneratedFilesUpToDate checks that the options_gen.go files of the client packages
+// match the declarations of internal/core. Run go generate ./... if it fails.
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	for _, pkg := range []string{"genshin", "hsr", "zzz", "enka"} {
+		want, err := generate("../../core", pkg)
+		if err != nil {
+			t.Fatalf("%s: %v", pkg, err)
+		}
+		got, err := os.ReadFile("../../../client/" + pkg + "/options_gen.go")
+		if err != nil {
+			t.Fatalf("%s: %v", pkg, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s: options_gen.go is out of date; run go generate ./...", pkg)
+		}
+	}
+}
+
+// TestQualify checks that the examples are rewritten for the client package, except those
+// combining several client packages.
+func TestQualify(t *testing.T) {
+	tests := []struct {
+		doc  string
+		pkg  string
+		want string
+	}{
+		{"\tclient := genshin.New(genshin.WithNoRetry())", "hsr", "\tclient := hsr.New(hsr.WithNoRetry())"},
+		{"\tclient := genshin.New(genshin.WithNoRetry())", "genshin", "\tclient := genshin.New(genshin.WithNoRetry())"},
+		{"e.g. \"genshin-bot:genshin_618285856\"", "zzz", "e.g. \"genshin-bot:genshin_618285856\""},
+		{"\tgi := genshin.New(genshin.WithRetryBudget(b))\n\tsr := hsr.New(hsr.WithRetryBudget(b))", "enka", "\tgi := genshin.New(genshin.WithRetryBudget(b))\n\tsr := hsr.New(hsr.WithRetryBudget(b))"},
+	}
+
+	for _, tt := range tests {
+		if got := qualify(tt.doc, tt.pkg); got != tt.want {
+			t.Errorf("qualify(%q, %q) = %q, want %q", tt.doc, tt.pkg, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"slices"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// GetBuilds returns the builds of a hoyo account of an Enka user decoded into the
+// build type B of a game client, keeping only those for which keep returns true.
+// It is shared by the GetBuilds methods of the genshin, hsr and zzz clients, which
+// differ only in game, the prefix of the cache key, and the hoyo_type matched by keep.
+//
+// The builds are returned ordered by the numeric avatarID of their character, in the
+// order the API lists them for each character. The result is cached for
+// DefaultCacheTTL, clamped with ClampCacheTTL, since builds do not include a TTL value.
+//
+// Parameters:
+//   - ctx: A context.Context to control the request's timeout or cancellation.
+//   - c: The client used for the cache, the URL and the request coalescing.
+//   - game: The prefix of the cache key, such as "genshin".
+//   - username: The username of the EnkaNetwork user.
+//   - hoyoHash: The hash of the hoyo account.
+//   - fetch: Fetches and decodes the builds map from the given URL, usually the
+//     FetchWithRetry method of a fetcher.Fetcher.
+//   - keep: Reports whether a build belongs to the game.
+//
+// Possible errors include:
+//   - errors.ErrInvalidUsername: If the username is empty or invalid.
+//   - errors.ErrInvalidHoyoHash: If the hoyo hash is empty or invalid.
+//   - errors.ErrHoyoAccountBuildsNotFound: If the user or hoyo account does not exist.
+//   - Any other error returned by fetch.
+func GetBuilds[B any](ctx context.Context, c *Client, game, username, hoyoHash string, fetch func(ctx context.Context, url string) (*map[string][]B, error), keep func(B) bool) ([]B, error) {
+	if !IsValidUsername(username) {
+		return nil, errors.ErrInvalidUsername
+	}
+
+	if !IsValidHoyoHash(hoyoHash) {
+		return nil, errors.ErrInvalidHoyoHash
+	}
+
+	key := c.LocalizedCacheKey(game, "user", username, "hoyos", hoyoHash, "builds")
+
+	if c.Cache != nil && !BypassCacheFromContext(ctx) {
+		if cached, ok := c.Cache.Get(key); ok {
+			if builds, ok := CachedAs[[]B](c, key, cached); ok {
+				return builds, nil
+			}
+		}
+	}
+
+	url := c.LocalizedURL(EnkaBuildsPath(username, hoyoHash))
+
+	return Do(ctx, c, key, func(ctx context.Context) ([]B, error) {
+		buildsMap, err := fetch(ctx, url)
+		if err != nil {
+			if errors.Is(err, errors.ErrPlayerNotFound) {
+				return nil, errors.ErrHoyoAccountBuildsNotFound
+			}
+			return StaleOnError[[]B](c, key, err)
+		}
+
+		builds := FilterBuilds(*buildsMap, keep)
+
+		if c.Cache != nil {
+			c.Cache.Set(key, builds, c.ClampCacheTTL(DefaultCacheTTL))
+		}
+
+		return builds, nil
+	})
+}
+
+// FilterBuilds flattens a map of builds keyed by avatarID into a slice of the builds
+// for which keep returns true. The characters are ordered by their numeric avatarID
+// (see CompareNumeric), and the builds of each character keep their order in the map.
+// It never returns nil.
+func FilterBuilds[B any](buildsMap map[string][]B, keep func(B) bool) []B {
+	avatarIDs := make([]string, 0, len(buildsMap))
+	for avatarID := range buildsMap {
+		avatarIDs = append(avatarIDs, avatarID)
+	}
+	slices.SortFunc(avatarIDs, CompareNumeric)
+
+	builds := []B{}
+	for _, avatarID := range avatarIDs {
+		for _, build := range buildsMap[avatarID] {
+			if keep(build) {
+				builds = append(builds, build)
+			}
+		}
+	}
+
+	return builds
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/kirinyoku/enkanetwork-go/cache"
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// testBuild is a minimal build type used to test the generic build helpers.
+type testBuild struct {
+	ID       int
+	HoyoType int
+}
+
+// TestFilterBuilds checks that builds are filtered by hoyo type and ordered by numeric avatarID.
+func TestFilterBuilds(t *testing.T) {
+	buildsMap := map[string][]testBuild{
+		"10000089": {{ID: 1, HoyoType: 0}, {ID: 2, HoyoType: 1}, {ID: 3, HoyoType: 0}},
+		"1310":     {{ID: 4, HoyoType: 1}},
+		"10000002": {{ID: 5, HoyoType: 0}},
+		"1005":     {{ID: 6, HoyoType: 1}, {ID: 7, HoyoType: 1}},
+	}
+
+	tests := []struct {
+		hoyoType int
+		want     []testBuild
+	}{
+		{0, []testBuild{{ID: 5, HoyoType: 0}, {ID: 1, HoyoType: 0}, {ID: 3, HoyoType: 0}}},
+		{1, []testBuild{{ID: 6, HoyoType: 1}, {ID: 7, HoyoType: 1}, {ID: 4, HoyoType: 1}, {ID: 2, HoyoType: 1}}},
+		{2, []testBuild{}},
+	}
+
+	for _, tt := range tests {
+		got := FilterBuilds(buildsMap, func(b testBuild) bool { return b.HoyoType == tt.hoyoType })
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FilterBuilds(hoyo type %d) = %v, want %v", tt.hoyoType, got, tt.want)
+		}
+	}
+}
+
+// TestGetBuilds checks the validation of the arguments, the mapping of ErrPlayerNotFound
+// and that the filtered builds are cached.
+func TestGetBuilds(t *testing.T) {
+	keep := func(b testBuild) bool { return b.HoyoType == 1 }
+
+	tests := []struct {
+		name     string
+		username string
+		hoyoHash string
+		fetchErr error
+		want     []testBuild
+		wantErr  error
+	}{
+		{"invalid username", "", "4Wjv2e", nil, nil, errors.ErrInvalidUsername},
+		{"invalid hoyo hash", "Algoinde", "", nil, nil, errors.ErrInvalidHoyoHash},
+		{"not found", "Algoinde", "4Wjv2e", errors.ErrPlayerNotFound, nil, errors.ErrHoyoAccountBuildsNotFound},
+		{"ok", "Algoinde", "4Wjv2e", nil, []testBuild{{ID: 2, HoyoType: 1}}, nil},
+	}
+
+	for _, tt := range tests {
+		c := New(WithCache(cache.NewLRU(10)))
+
+		calls := 0
+		fetch := func(ctx context.Context, url string) (*map[string][]testBuild, error) {
+			calls++
+			if want := c.URL(EnkaBuildsPath(tt.username, tt.hoyoHash)); url != want {
+				t.Errorf("%s: url = %q, want %q", tt.name, url, want)
+			}
+			if tt.fetchErr != nil {
+				return nil, tt.fetchErr
+			}
+			return &map[string][]testBuild{"1310": {{ID: 1, HoyoType: 0}, {ID: 2, HoyoType: 1}}}, nil
+		}
+
+		for range 2 {
+			got, err := GetBuilds(context.Background(), c, "hsr", tt.username, tt.hoyoHash, fetch, keep)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("%s: error = %v, want %v", tt.name, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("%s: builds = %v, want %v", tt.name, got, tt.want)
+			}
+		}
+
+		wantCalls := 0
+		switch {
+		case tt.fetchErr != nil:
+			wantCalls = 2
+		case tt.wantErr == nil:
+			wantCalls = 1
+		}
+		if calls != wantCalls {
+			t.Errorf("%s: fetch called %d times, want %d", tt.name, calls, wantCalls)
+		}
+	}
+}
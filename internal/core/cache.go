@@ -1,6 +1,11 @@
 package core
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
 
 // Cache defines an interface for caching API responses.
 // Caching helps reduce the number of requests to the API, which is important because
@@ -16,3 +21,115 @@ type Cache interface {
 	// The expiration time determines how long the value remains valid.
 	Set(key string, value any, expiration time.Duration)
 }
+
+// DefaultCacheTTL is how long responses without a ttl field, such as the Enka user
+// profiles and builds, are cached before MinCacheTTL and MaxCacheTTL are applied.
+const DefaultCacheTTL = 5 * time.Minute
+
+// CacheTTL converts the ttl value of an API response, in seconds, into the expiration
+// used to cache the response. It returns false if the response must not be cached.
+//
+// A ttl of zero or less means the API did not report how long the data remains valid.
+// Cache implementations disagree on what a zero expiration means (some never expire
+// the value, others drop it immediately), so such responses are not cached at all and
+// the next request goes to the API again.
+func CacheTTL(ttl int) (time.Duration, bool) {
+	if ttl <= 0 {
+		return 0, false
+	}
+	return time.Duration(ttl) * time.Second, true
+}
+
+// CacheTTL is like the CacheTTL function, but clamps the expiration between the
+// MinCacheTTL and MaxCacheTTL of the client (see ClampCacheTTL).
+func (c *Client) CacheTTL(ttl int) (time.Duration, bool) {
+	expiration, ok := CacheTTL(ttl)
+	if !ok {
+		return 0, false
+	}
+	return c.ClampCacheTTL(expiration), true
+}
+
+// ClampCacheTTL returns expiration raised to the MinCacheTTL of the client and lowered to
+// its MaxCacheTTL, ignoring the bounds that are zero or less.
+func (c *Client) ClampCacheTTL(expiration time.Duration) time.Duration {
+	if c.MinCacheTTL > 0 && expiration < c.MinCacheTTL {
+		expiration = c.MinCacheTTL
+	}
+	if c.MaxCacheTTL > 0 && expiration > c.MaxCacheTTL {
+		expiration = c.MaxCacheTTL
+	}
+	return expiration
+}
+
+// namespacedCache is a Cache that prefixes all keys before passing them to another Cache.
+type namespacedCache struct {
+	prefix string
+	inner  Cache
+}
+
+// NamespacedCache returns a Cache that stores its entries in inner under keys prefixed
+// with prefix and a colon, e.g. "genshin-bot:genshin_618285856" for the prefix
+// "genshin-bot". It allows several clients to share a single cache, such as a Redis
+// instance, with each client confined to its own namespace: a client can only read the
+// entries it stored itself, and the entries of one client can be removed by the key
+// pattern "prefix:*".
+//
+// Cache keys built by the clients never contain a colon, so entries of different
+// namespaces cannot collide. If inner is nil, NamespacedCache returns nil, which
+// disables caching.
+//
+// Example:
+//
+//	shared := newRedisCache()
+//	gi := genshin.New(genshin.WithCache(genshin.NamespacedCache("genshin", shared)))
+//	sr := hsr.New(hsr.WithCache(hsr.NamespacedCache("hsr", shared)))
+func NamespacedCache(prefix string, inner Cache) Cache {
+	if inner == nil {
+		return nil
+	}
+	return &namespacedCache{prefix: prefix + ":", inner: inner}
+}
+
+// Get retrieves a value from the inner cache by the prefixed key.
+func (c *namespacedCache) Get(key string) (any, bool) {
+	return c.inner.Get(c.prefix + key)
+}
+
+// GetStale retrieves a possibly expired value from the inner cache by the prefixed key,
+// if the inner cache implements StaleCache.
+func (c *namespacedCache) GetStale(key string) (any, bool) {
+	if stale, ok := c.inner.(StaleCache); ok {
+		return stale.GetStale(c.prefix + key)
+	}
+	return nil, false
+}
+
+// Set stores a value in the inner cache under the prefixed key.
+func (c *namespacedCache) Set(key string, value any, expiration time.Duration) {
+	c.inner.Set(c.prefix+key, value, expiration)
+}
+
+// CachedAs returns cached, a value read from the cache under key, as a T. If it is not a
+// T, the zero value and false are returned, and the mismatch is reported to the client's
+// CacheErrorHandler: a cached value implementing error, such as one stored by a cache in
+// place of a value it failed to decode, is reported as is, and any other value with an
+// error wrapping errors.ErrCacheTypeMismatch.
+//
+// Game-specific clients call it in place of a type assertion, e.g.
+// profile, ok := core.CachedAs[*Profile](c.Client, key, cached).
+func CachedAs[T any](c *Client, key string, cached any) (T, bool) {
+	value, ok := cached.(T)
+	if ok {
+		return value, true
+	}
+
+	if c.CacheErrorHandler != nil {
+		err, isErr := cached.(error)
+		if !isErr {
+			err = fmt.Errorf("%w: got %T, want %T", errors.ErrCacheTypeMismatch, cached, value)
+		}
+		c.CacheErrorHandler(key, err)
+	}
+	return value, false
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// TestCacheTTL checks that responses without a positive TTL are not cached.
+func TestCacheTTL(t *testing.T) {
+	tests := []struct {
+		ttl  int
+		want time.Duration
+		ok   bool
+	}{
+		{60, time.Minute, true},
+		{1, time.Second, true},
+		{0, 0, false},
+		{-1, 0, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := CacheTTL(tt.ttl)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("CacheTTL(%d) = %v, %v, want %v, %v", tt.ttl, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// mapCache is a Cache storing its entries in a map, ignoring expiration.
+type mapCache map[string]any
+
+func (c mapCache) Get(key string) (any, bool) {
+	v, ok := c[key]
+	return v, ok
+}
+
+func (c mapCache) Set(key string, value any, expiration time.Duration) {
+	c[key] = value
+}
+
+// TestNamespacedCache checks that namespaces sharing a cache are isolated.
+func TestNamespacedCache(t *testing.T) {
+	shared := mapCache{}
+	genshin := NamespacedCache("genshin", shared)
+	hsr := NamespacedCache("hsr", shared)
+
+	genshin.Set("key", 1, time.Minute)
+	hsr.Set("key", 2, time.Minute)
+
+	if v, ok := genshin.Get("key"); !ok || v != 1 {
+		t.Errorf("genshin.Get() = %v, %v, want 1, true", v, ok)
+	}
+	if v, ok := hsr.Get("key"); !ok || v != 2 {
+		t.Errorf("hsr.Get() = %v, %v, want 2, true", v, ok)
+	}
+	if _, ok := shared["genshin:key"]; !ok {
+		t.Errorf("expected the key to be prefixed, got %v", shared)
+	}
+
+	if c := NamespacedCache("genshin", nil); c != nil {
+		t.Errorf("expected nil for a nil inner cache, got %v", c)
+	}
+}
+
+// TestClientCacheTTL checks that expirations are clamped between MinCacheTTL and MaxCacheTTL.
+func TestClientCacheTTL(t *testing.T) {
+	c := New(WithMinCacheTTL(time.Minute), WithMaxCacheTTL(time.Hour))
+
+	tests := []struct {
+		ttl    int
+		want   time.Duration
+		wantOK bool
+	}{
+		{0, 0, false},
+		{5, time.Minute, true},
+		{600, 10 * time.Minute, true},
+		{7200, time.Hour, true},
+	}
+
+	for _, tt := range tests {
+		got, ok := c.CacheTTL(tt.ttl)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("CacheTTL(%d) = %v, %v, want %v, %v", tt.ttl, got, ok, tt.want, tt.wantOK)
+		}
+	}
+
+	if got := New().ClampCacheTTL(DefaultCacheTTL); got != DefaultCacheTTL {
+		t.Errorf("ClampCacheTTL() without bounds = %v, want %v", got, DefaultCacheTTL)
+	}
+}
+
+// TestCachedAs checks that cached values of an unexpected type are reported to the CacheErrorHandler.
+func TestCachedAs(t *testing.T) {
+	var reported []error
+	c := New(WithCacheErrorHandler(func(key string, err error) {
+		if key != "key" {
+			t.Errorf("handler called with key %q, want \"key\"", key)
+		}
+		reported = append(reported, err)
+	}))
+	decodeErr := fmt.Errorf("decoding failed")
+
+	if value, ok := CachedAs[int](c, "key", 1); !ok || value != 1 {
+		t.Errorf("CachedAs(1) = %v, %v, want 1, true", value, ok)
+	}
+	if _, ok := CachedAs[int](c, "key", "1"); ok {
+		t.Error("CachedAs(\"1\") succeeded, want a mismatch")
+	}
+	if _, ok := CachedAs[int](c, "key", decodeErr); ok {
+		t.Error("CachedAs(error) succeeded, want a mismatch")
+	}
+
+	if len(reported) != 2 || !errors.Is(reported[0], errors.ErrCacheTypeMismatch) || reported[1] != decodeErr {
+		t.Errorf("reported errors = %v, want a type mismatch and the cached error", reported)
+	}
+}
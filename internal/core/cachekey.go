@@ -0,0 +1,25 @@
+package core
+
+import "strings"
+
+// cacheKeyEscaper escapes the cache key separator in key parts. The escape character
+// itself is escaped first, so different parts can never produce the same key.
+var cacheKeyEscaper = strings.NewReplacer("%", "%25", "_", "%5F")
+
+// CacheKey builds the key under which a response is stored in the cache. The key
+// consists of the game (or "enka" for EnkaNetwork profiles) followed by the given
+// parts, separated by underscores, e.g. CacheKey("genshin", "618285856") returns
+// "genshin_618285856".
+//
+// Underscores and percent signs in the game and parts are percent-encoded, so parts
+// containing the separator, such as usernames, cannot produce the key of a different
+// request: CacheKey("enka", "a_b", "c") and CacheKey("enka", "a", "b_c") differ.
+func CacheKey(game string, parts ...string) string {
+	var b strings.Builder
+	b.WriteString(cacheKeyEscaper.Replace(game))
+	for _, part := range parts {
+		b.WriteByte('_')
+		b.WriteString(cacheKeyEscaper.Replace(part))
+	}
+	return b.String()
+}
@@ -0,0 +1,39 @@
+package core
+
+import "testing"
+
+// TestCacheKey checks the format of cache keys.
+func TestCacheKey(t *testing.T) {
+	tests := []struct {
+		game  string
+		parts []string
+		want  string
+	}{
+		{"genshin", []string{"618285856"}, "genshin_618285856"},
+		{"genshin", []string{"618285856", "info"}, "genshin_618285856_info"},
+		{"enka", []string{"user", "a_b"}, "enka_user_a%5Fb"},
+		{"enka", []string{"user", "100%"}, "enka_user_100%25"},
+	}
+
+	for _, tt := range tests {
+		if got := CacheKey(tt.game, tt.parts...); got != tt.want {
+			t.Errorf("CacheKey(%q, %q) = %q, want %q", tt.game, tt.parts, got, tt.want)
+		}
+	}
+}
+
+// TestCacheKeyNoCollision checks that parts containing the separator cannot collide.
+func TestCacheKeyNoCollision(t *testing.T) {
+	pairs := [][2][]string{
+		{{"user", "a_b", "c"}, {"user", "a", "b_c"}},
+		{{"user", "a_hoyos"}, {"user", "a", "hoyos"}},
+		{{"user", "a%5Fb"}, {"user", "a_b"}},
+	}
+
+	for _, pair := range pairs {
+		a, b := CacheKey("enka", pair[0]...), CacheKey("enka", pair[1]...)
+		if a == b {
+			t.Errorf("CacheKey(%q) and CacheKey(%q) collide: %q", pair[0], pair[1], a)
+		}
+	}
+}
@@ -1,17 +1,46 @@
 package core
 
 import (
+	"context"
+	"io"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
+	"unicode"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+	"golang.org/x/sync/semaphore"
+	"golang.org/x/sync/singleflight"
 )
 
 // BaseURL is the root URL for the EnkaNetwork API, used as the starting point for all
 // API requests. Each game (Genshin Impact, Honkai: Star Rail, Zenless Zone Zero) builds
 // specific endpoints by adding paths to this URL.
+//
+// WebURL is the root URL of the EnkaNetwork website, used to build links to the pages
+// of users and showcases.
 const (
 	BaseURL = "https://enka.network/api"
+	WebURL  = "https://enka.network"
 )
 
+// HTTPDoer sends HTTP requests and returns their responses. *http.Client implements it,
+// and tests can provide an HTTPDoerFunc returning canned responses or errors instead of
+// running an HTTP server.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// HTTPDoerFunc adapts a function to the HTTPDoer interface.
+type HTTPDoerFunc func(req *http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f HTTPDoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
 // Client represents an EnkaNetwork API client used to make requests to the API.
 // It holds an HTTP client for sending requests, an optional cache for storing
 // responses, and a User-Agent string to identify the client in API requests.
@@ -22,10 +51,244 @@ const (
 //   - Cache: An optional cache implementation to store API responses locally.
 //   - UserAgent: A string sent in the User-Agent header of every request to identify
 //     your application.
+//   - Retry: The retry configuration for requests failing with a transient error.
+//   - RetryOnMaintenance: Whether 424 maintenance responses are retried like other
+//     transient errors.
+//   - RateLimiter: An optional rate limiter waited on before every request.
+//   - RequestTimeout: An optional timeout applied to each request attempt.
+//   - FallbackTimeout: The timeout of request attempts that are otherwise unbounded.
+//   - ETags: An optional store of response ETags used for conditional requests.
+//   - Headers: Additional headers sent with every request.
+//   - MaxResponseSize: The maximum size of a response body in bytes.
+//   - Clock: The clock used to wait between retries and to interpret Retry-After dates.
+//   - APIVersion: An optional version inserted between BaseURL and the endpoint paths.
+//   - Language: An optional language code sent with the endpoints that support localization.
+//   - RetryBudget: An optional budget bounding the retries of all requests that share it.
+//   - ServeStaleOnError: Whether expired cached values are returned when a request fails
+//     with a transient error.
+//   - Doer: An optional HTTPDoer that sends the requests instead of HTTPClient.
+//   - MinCacheTTL, MaxCacheTTL: Optional bounds of how long responses are cached.
+//   - NotFoundTTL: How long the absence of a player is cached, or zero to not cache it.
+//   - CaseInsensitiveUsernames: Whether Enka usernames are cached regardless of case.
+//   - CacheErrorHandler: An optional function called when a cached value cannot be used.
+//   - MaxConcurrency: The maximum number of requests in flight at once, or zero for no limit.
 type Client struct {
-	HTTPClient *http.Client // HTTP client for making requests
-	Cache      Cache        // Optional cache for storing API responses
-	UserAgent  string       // User-Agent string for HTTP requests
+	HTTPClient     *http.Client  // HTTP client for making requests
+	Cache          Cache         // Optional cache for storing API responses
+	UserAgent      string        // User-Agent string for HTTP requests
+	Retry          RetryConfig   // Retry configuration for transient errors
+	RateLimiter    RateLimiter   // Optional rate limiter for outgoing requests
+	RequestTimeout time.Duration // Optional timeout for each request attempt
+	ETags          *ETagStore    // Optional ETag store for conditional requests
+	Headers        http.Header   // Additional headers for HTTP requests
+
+	MaxResponseSize int64 // Maximum size of a response body in bytes
+	Clock           Clock // Clock used for retry delays
+
+	FallbackTimeout time.Duration // Timeout of attempts without any other deadline
+
+	APIVersion string // Optional version prefix of the endpoint paths
+	Language   string // Optional language of localized responses
+
+	RetryBudget        *RetryBudget // Optional budget of retries shared across requests
+	RetryOnMaintenance bool         // Whether 424 maintenance responses are retried
+	ServeStaleOnError  bool         // Whether stale cached values are returned on transient errors
+
+	Doer HTTPDoer // Optional HTTP doer used instead of HTTPClient
+
+	MinCacheTTL time.Duration // Optional lower bound of the cache expiration of responses
+	MaxCacheTTL time.Duration // Optional upper bound of the cache expiration of responses
+	NotFoundTTL time.Duration // Optional cache expiration of player not found results
+
+	CaseInsensitiveUsernames bool // Whether usernames differing only in case share cache entries
+
+	CacheErrorHandler func(key string, err error) // Optional handler of unusable cached values
+
+	MaxConcurrency int // Optional maximum number of requests in flight at once
+
+	transport        http.RoundTripper   // Transport installed on HTTPClient by New
+	requireUserAgent bool                // Whether a custom User-Agent must be provided
+	userAgentSuffix  string              // Suffix appended to the User-Agent by New
+	err              error               // Configuration error reported by Err
+	group            singleflight.Group  // Coalesces concurrent requests for the same key
+	concurrency      *semaphore.Weighted // Limits requests in flight to MaxConcurrency
+	closeOnce        sync.Once           // Makes Close idempotent
+	closeErr         error               // Error returned by Close
+}
+
+// maxUserAgentLength is the maximum accepted length of a User-Agent string.
+const maxUserAgentLength = 256
+
+// Err returns the configuration error detected when the client was created, or nil
+// if the configuration is valid. A client with a configuration error returns the same
+// error from every request without contacting the API.
+//
+// Possible errors include:
+//   - errors.ErrUserAgentRequired: If WithRequireUserAgent was used without a User-Agent.
+//   - errors.ErrInvalidUserAgent: If the User-Agent contains control characters or is
+//     longer than 256 characters.
+//   - errors.ErrInvalidLanguage: If the language set with WithLanguage is not one of
+//     SupportedLanguages.
+func (c *Client) Err() error {
+	return c.err
+}
+
+// Send sends req with the Doer of the client, or with HTTPClient if no Doer is set.
+func (c *Client) Send(req *http.Request) (*http.Response, error) {
+	if c.Doer != nil {
+		return c.Doer.Do(req)
+	}
+	if c.HTTPClient == nil {
+		return http.DefaultClient.Do(req)
+	}
+	return c.HTTPClient.Do(req)
+}
+
+// AttemptContext returns the context used for a single request attempt and the function
+// releasing it. If the client has a RequestTimeout, the context expires after it. If
+// not, and neither ctx nor the HTTP client bounds the request, the context expires after
+// FallbackTimeout, so a hung connection cannot block forever. A client with a Doer is
+// assumed to be unbounded. A shorter deadline already set on ctx always takes precedence.
+func (c *Client) AttemptContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.RequestTimeout > 0 {
+		return context.WithTimeout(ctx, c.RequestTimeout)
+	}
+	if _, ok := ctx.Deadline(); ok || c.FallbackTimeout <= 0 {
+		return ctx, func() {}
+	}
+	if c.Doer == nil && c.HTTPClient != nil && c.HTTPClient.Timeout > 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, c.FallbackTimeout)
+}
+
+// UsernameCacheKey returns the form of username used in cache keys: username lowercased
+// if the client has CaseInsensitiveUsernames, or username unchanged otherwise. Requests
+// are always sent with the username as given.
+func (c *Client) UsernameCacheKey(username string) string {
+	if c.CaseInsensitiveUsernames {
+		return strings.ToLower(username)
+	}
+	return username
+}
+
+// URL returns the URL of the endpoint with the given path, such as one returned by
+// GenshinUIDPath. If the client has an APIVersion, it is inserted between BaseURL and
+// path (e.g., "https://enka.network/api/v2/uid/618285856").
+func (c *Client) URL(path string) string {
+	if version := strings.Trim(c.APIVersion, "/"); version != "" {
+		return BaseURL + "/" + version + path
+	}
+	return BaseURL + path
+}
+
+// LocalizedURL is like URL, but adds the lang query parameter if the client has a
+// Language. It is used for the endpoints that support localization, such as the builds
+// of a hoyo account.
+func (c *Client) LocalizedURL(path string) string {
+	if c.Language == "" {
+		return c.URL(path)
+	}
+	return c.URL(path) + "?lang=" + url.QueryEscape(c.Language)
+}
+
+// LocalizedCacheKey is like CacheKey, but adds the Language of the client to the key, so
+// responses fetched with LocalizedURL in different languages are cached separately.
+func (c *Client) LocalizedCacheKey(game string, parts ...string) string {
+	if c.Language == "" {
+		return CacheKey(game, parts...)
+	}
+	return CacheKey(game, append(parts, "lang", c.Language)...)
+}
+
+// Close stops the background goroutines of the resources used by the client, such as
+// the expiration janitor of a cache created with cache.NewLRU. The Cache and RateLimiter
+// are closed if they have a Close method, with or without an error result. Close is
+// safe to call multiple times; later calls return the result of the first one.
+//
+// After Close the client must not be used anymore. A cache shared with other clients
+// is closed for them as well; wrapping it with NamespacedCache for each client keeps
+// it open, since the wrapper has no Close method.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		c.closeErr = errors.Join(closeResource(c.Cache), closeResource(c.RateLimiter))
+	})
+	return c.closeErr
+}
+
+// closeResource calls the Close method of v, if it has one, and returns its error.
+func closeResource(v any) error {
+	switch v := v.(type) {
+	case io.Closer:
+		return v.Close()
+	case interface{ Close() }:
+		v.Close()
+	}
+	return nil
+}
+
+// New creates and configures a new Client instance from the given options. It is used
+// internally by the New function of the game-specific clients (e.g., genshin.New,
+// hsr.New).
+//
+// Options that are not provided fall back to the same defaults as NewClient: an HTTP
+// client with a 10-second timeout, no cache, DefaultUserAgent, DefaultRetryConfig, DefaultMaxResponseSize, DefaultFallbackTimeout and
+// DefaultClock.
+//
+// The User-Agent is trimmed of surrounding whitespace, followed by the suffix set with
+// WithUserAgentSuffix, if any, and validated. If it is invalid,
+// or missing while WithRequireUserAgent is used, the error is reported by Err.
+func New(opts ...Option) *Client {
+	c := &Client{FallbackTimeout: DefaultFallbackTimeout}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.HTTPClient == nil {
+		c.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if c.transport != nil {
+		// Copy the client so that one provided with WithHTTPClient is not modified
+		httpClient := *c.HTTPClient
+		httpClient.Transport = c.transport
+		c.HTTPClient = &httpClient
+	}
+	c.UserAgent = strings.TrimSpace(c.UserAgent)
+	if c.UserAgent == "" {
+		if c.requireUserAgent && c.userAgentSuffix == "" {
+			c.err = errors.ErrUserAgentRequired
+		}
+		c.UserAgent = DefaultUserAgent
+	}
+	if c.userAgentSuffix != "" {
+		c.UserAgent += " " + c.userAgentSuffix
+	}
+	if c.err == nil && !isValidUserAgent(c.UserAgent) {
+		c.err = errors.ErrInvalidUserAgent
+	}
+	if c.err == nil && c.Language != "" && !IsValidLanguage(c.Language) {
+		c.err = errors.ErrInvalidLanguage
+	}
+	if c.Clock == nil {
+		c.Clock = DefaultClock
+	}
+	if c.MaxResponseSize <= 0 {
+		c.MaxResponseSize = DefaultMaxResponseSize
+	}
+	if c.Retry.MaxAttempts <= 0 {
+		c.Retry.MaxAttempts = DefaultRetryConfig.MaxAttempts
+	}
+	if c.Retry.DefaultDelay <= 0 {
+		c.Retry.DefaultDelay = DefaultRetryConfig.DefaultDelay
+	}
+	if c.Retry.MaxRetryAfter <= 0 {
+		c.Retry.MaxRetryAfter = DefaultRetryConfig.MaxRetryAfter
+	}
+	if c.MaxConcurrency > 0 {
+		c.concurrency = semaphore.NewWeighted(int64(c.MaxConcurrency))
+	}
+
+	return c
 }
 
 // NewClient creates and configures a new Client instance for making requests to the
@@ -46,22 +309,62 @@ type Client struct {
 //     directly to the API. Caching is recommended to reduce the number of requests and
 //     stay within the API’s rate limits.
 //   - userAgent: A string that identifies your application in API requests. If you provide
-//     an empty string, the function sets a default User-Agent of
-//     "enka-network-go-client/1.0". It’s a good idea to use a unique User-Agent, like
+//     an empty string, the function sets DefaultUserAgent. It’s a good idea to use a unique User-Agent, like
 //     "my-game-app/1.0", to help the API team know who’s using their service.
 //
 // The function returns a pointer to a fully configured Client, ready to be used by
 // game-specific client to make API requests.
 func NewClient(httpClient *http.Client, cache Cache, userAgent string) *Client {
-	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 10 * time.Second}
+	return New(WithHTTPClient(httpClient), WithCache(cache), WithUserAgent(userAgent))
+}
+
+// isValidUserAgent reports whether userAgent can be sent as a User-Agent header: it
+// must not contain control characters and must not exceed maxUserAgentLength.
+func isValidUserAgent(userAgent string) bool {
+	if len(userAgent) > maxUserAgentLength {
+		return false
 	}
-	if userAgent == "" {
-		userAgent = "enka-network-go-client/1.0"
+	for _, r := range userAgent {
+		if unicode.IsControl(r) {
+			return false
+		}
 	}
-	return &Client{
-		HTTPClient: httpClient,
-		Cache:      cache,
-		UserAgent:  userAgent,
+	return true
+}
+
+// Do executes fn for the given key, making sure that only one execution is in flight
+// for a key at a time. If a duplicate call comes in while fn is running, the caller
+// waits for the original call to complete and receives the same result, including the
+// error. The key is forgotten once the call completes, so later calls run fn again.
+//
+// Game-specific clients use the cache key of a request as the key, so concurrent
+// requests for the same resource result in a single API call. The shared call runs with
+// the values of the first caller's ctx but without its cancellation, so a caller that
+// gives up does not fail the others: each caller waits for the result until its own ctx
+// is done, and then returns ctx.Err(). An unbounded shared call is still limited by the
+// fallback timeout (see AttemptContext).
+//
+// Calls whose ctx carries a request ID (WithRequestID) or request headers
+// (WithRequestHeaders) are not coalesced, since they must be sent with their own
+// headers.
+//
+// The result is shared by all the callers, like a cached value, so it must not be
+// modified.
+func Do[T any](ctx context.Context, c *Client, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	if _, ok := RequestIDFromContext(ctx); ok || RequestHeadersFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	ch := c.group.DoChan(key, func() (any, error) {
+		return fn(context.WithoutCancel(ctx))
+	})
+
+	select {
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	case res := <-ch:
+		result, _ := res.Val.(T)
+		return result, res.Err
 	}
 }
@@ -0,0 +1,291 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// TestDoCoalescesConcurrentCalls checks that concurrent calls for the same key share a single execution.
+func TestDoCoalescesConcurrentCalls(t *testing.T) {
+	c := New()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	var wg, ready sync.WaitGroup
+	results := make([]int, 10)
+	for i := range results {
+		wg.Add(1)
+		ready.Add(1)
+		go func() {
+			defer wg.Done()
+			ready.Done()
+			results[i], _ = Do(context.Background(), c, "genshin_618285856", func(context.Context) (int, error) {
+				calls.Add(1)
+				<-release
+				return 42, nil
+			})
+		}()
+	}
+
+	// Give all goroutines time to join the in-flight call before releasing it.
+	ready.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected 1 call, got %d", n)
+	}
+	for i, got := range results {
+		if got != 42 {
+			t.Errorf("result %d: expected 42, got %d", i, got)
+		}
+	}
+}
+
+// TestDoCanceledCaller checks that a caller giving up neither cancels the shared call
+// nor fails the other callers.
+func TestDoCanceledCaller(t *testing.T) {
+	c := New()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var sharedErr atomic.Value
+
+	ctx, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := Do(ctx, c, "genshin_618285856", func(ctx context.Context) (int, error) {
+			close(started)
+			<-release
+			if err := ctx.Err(); err != nil {
+				sharedErr.Store(err)
+			}
+			return 42, nil
+		})
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan int, 1)
+	go func() {
+		v, _ := Do(context.Background(), c, "genshin_618285856", func(context.Context) (int, error) {
+			t.Error("expected the second call to join the first one")
+			return 0, nil
+		})
+		second <- v
+	}()
+
+	// Give the second caller time to join the in-flight call before canceling the first.
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	if err := <-firstErr; err != context.Canceled {
+		t.Errorf("first caller: expected context.Canceled, got %v", err)
+	}
+
+	close(release)
+	if v := <-second; v != 42 {
+		t.Errorf("second caller: expected 42, got %d", v)
+	}
+	if err := sharedErr.Load(); err != nil {
+		t.Errorf("shared call: expected a live context, got %v", err)
+	}
+}
+
+// TestDoRequestScopedNotCoalesced checks that calls with their own request ID or headers
+// are not merged with other calls.
+func TestDoRequestScopedNotCoalesced(t *testing.T) {
+	c := New()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+
+	ctxs := []context.Context{
+		WithRequestID(context.Background(), "a"),
+		WithRequestID(context.Background(), "b"),
+		WithRequestHeaders(context.Background(), map[string]string{"X-Trace": "1"}),
+	}
+
+	var wg sync.WaitGroup
+	for _, ctx := range ctxs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Do(ctx, c, "genshin_618285856", func(context.Context) (int, error) {
+				calls.Add(1)
+				<-release
+				return 42, nil
+			})
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := calls.Load(); int(n) != len(ctxs) {
+		t.Errorf("expected %d calls, got %d", len(ctxs), n)
+	}
+}
+
+// TestWithTransport checks that the transport is installed without dropping the other settings.
+func TestWithTransport(t *testing.T) {
+	rt := &http.Transport{}
+
+	c := New(WithTransport(rt), WithRequestTimeout(time.Second))
+	if c.HTTPClient.Transport == nil || c.HTTPClient.Timeout != 10*time.Second {
+		t.Errorf("default client = %+v, want transport and 10s timeout", c.HTTPClient)
+	}
+	if c.RequestTimeout != time.Second {
+		t.Errorf("RequestTimeout = %v, want %v", c.RequestTimeout, time.Second)
+	}
+
+	custom := &http.Client{Timeout: 30 * time.Second}
+	c = New(WithHTTPClient(custom), WithTransport(rt))
+	if c.HTTPClient.Transport == nil || c.HTTPClient.Timeout != 30*time.Second {
+		t.Errorf("custom client = %+v, want transport and 30s timeout", c.HTTPClient)
+	}
+	if custom.Transport != nil {
+		t.Errorf("client passed to WithHTTPClient was modified")
+	}
+}
+
+// closingCache is a Cache counting calls to Close.
+type closingCache struct {
+	Cache
+	closed atomic.Int32
+}
+
+func (c *closingCache) Close() {
+	c.closed.Add(1)
+}
+
+// TestClose checks that Close closes the cache once, however many times it is called.
+func TestClose(t *testing.T) {
+	cache := &closingCache{}
+	c := New(WithCache(cache))
+
+	for range 2 {
+		if err := c.Close(); err != nil {
+			t.Errorf("Close() = %v, want nil", err)
+		}
+	}
+	if n := cache.closed.Load(); n != 1 {
+		t.Errorf("cache closed %d times, want 1", n)
+	}
+}
+
+func TestClientURL(t *testing.T) {
+	tests := []struct {
+		version string
+		want    string
+	}{
+		{"", BaseURL + "/uid/618285856"},
+		{"v2", BaseURL + "/v2/uid/618285856"},
+		{"/v2/", BaseURL + "/v2/uid/618285856"},
+	}
+
+	for _, tt := range tests {
+		c := New(WithAPIVersion(tt.version))
+		if got := c.URL(GenshinUIDPath("618285856")); got != tt.want {
+			t.Errorf("URL() with version %q = %q, want %q", tt.version, got, tt.want)
+		}
+	}
+}
+
+func TestWithLanguage(t *testing.T) {
+	tests := []struct {
+		lang    string
+		wantErr error
+		wantURL string
+		wantKey string
+	}{
+		{"", nil, BaseURL + "/profile/Algoinde/hoyos/4Wjv2e/builds", "enka_builds"},
+		{"zh-CN", nil, BaseURL + "/profile/Algoinde/hoyos/4Wjv2e/builds?lang=zh-CN", "enka_builds_lang_zh-CN"},
+		{"xx", errors.ErrInvalidLanguage, "", ""},
+	}
+
+	for _, tt := range tests {
+		c := New(WithLanguage(tt.lang))
+		if err := c.Err(); err != tt.wantErr {
+			t.Errorf("Err() with language %q = %v, want %v", tt.lang, err, tt.wantErr)
+		}
+		if tt.wantErr != nil {
+			continue
+		}
+		if got := c.LocalizedURL(EnkaBuildsPath("Algoinde", "4Wjv2e")); got != tt.wantURL {
+			t.Errorf("LocalizedURL() with language %q = %q, want %q", tt.lang, got, tt.wantURL)
+		}
+		if got := c.LocalizedCacheKey("enka", "builds"); got != tt.wantKey {
+			t.Errorf("LocalizedCacheKey() with language %q = %q, want %q", tt.lang, got, tt.wantKey)
+		}
+	}
+}
+
+// TestAttemptContext checks that FallbackTimeout only applies when nothing else bounds a request.
+func TestAttemptContext(t *testing.T) {
+	deadline, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		opts []Option
+		want time.Duration // Expected timeout, or zero for no deadline
+	}{
+		{"default client", context.Background(), nil, 0},
+		{"client without timeout", context.Background(), []Option{WithHTTPClient(&http.Client{})}, DefaultFallbackTimeout},
+		{"doer", context.Background(), []Option{WithHTTPDoer(HTTPDoerFunc(nil))}, DefaultFallbackTimeout},
+		{"custom fallback", context.Background(), []Option{WithHTTPClient(&http.Client{}), WithFallbackTimeout(time.Minute)}, time.Minute},
+		{"disabled", context.Background(), []Option{WithHTTPClient(&http.Client{}), WithFallbackTimeout(0)}, 0},
+		{"context deadline", deadline, []Option{WithHTTPClient(&http.Client{})}, time.Hour},
+		{"request timeout", context.Background(), []Option{WithHTTPClient(&http.Client{}), WithRequestTimeout(time.Second)}, time.Second},
+	}
+
+	for _, tt := range tests {
+		ctx, cancel := New(tt.opts...).AttemptContext(tt.ctx)
+		got, ok := ctx.Deadline()
+		switch {
+		case tt.want == 0 && ok:
+			t.Errorf("%s: unexpected deadline in %v", tt.name, time.Until(got))
+		case tt.want != 0 && (!ok || time.Until(got) > tt.want || time.Until(got) < tt.want-time.Minute/2):
+			t.Errorf("%s: deadline in %v, want %v", tt.name, time.Until(got), tt.want)
+		}
+		cancel()
+	}
+}
+
+// TestUserAgentSuffix checks that the suffix is appended to the default or provided User-Agent.
+func TestUserAgentSuffix(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		want    string
+		wantErr error
+	}{
+		{"default", nil, DefaultUserAgent, nil},
+		{"suffix", []Option{WithUserAgentSuffix("my-app/2.3")}, DefaultUserAgent + " my-app/2.3", nil},
+		{"provided", []Option{WithUserAgent("sdk/1.0"), WithUserAgentSuffix(" my-app/2.3 ")}, "sdk/1.0 my-app/2.3", nil},
+		{"twice", []Option{WithUserAgentSuffix("sdk/1.0"), WithUserAgentSuffix("my-app/2.3")}, DefaultUserAgent + " sdk/1.0 my-app/2.3", nil},
+		{"required", []Option{WithRequireUserAgent(), WithUserAgentSuffix("my-app/2.3")}, DefaultUserAgent + " my-app/2.3", nil},
+		{"required without", []Option{WithRequireUserAgent()}, DefaultUserAgent, errors.ErrUserAgentRequired},
+		{"invalid", []Option{WithUserAgentSuffix("my-app\n/2.3")}, "", errors.ErrInvalidUserAgent},
+	}
+
+	for _, tt := range tests {
+		c := New(tt.opts...)
+		if err := c.Err(); err != tt.wantErr {
+			t.Errorf("%s: Err() = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if tt.wantErr == nil && c.UserAgent != tt.want {
+			t.Errorf("%s: UserAgent = %q, want %q", tt.name, c.UserAgent, tt.want)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package core
+
+import "time"
+
+// Clock defines an interface for reading the current time and waiting, used for the
+// delays between retries and for interpreting Retry-After dates. The default clock is
+// backed by the time package; tests can provide a fake clock to control time-dependent
+// behavior without real sleeps.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
+
+// DefaultClock is the clock used when none is provided, backed by the time package.
+var DefaultClock Clock = realClock{}
+
+// realClock is a Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"net/http"
+)
+
+// requestIDKey is the context key under which WithRequestID stores the request ID.
+type requestIDKey struct{}
+
+// requestHeadersKey is the context key under which WithRequestHeaders stores headers.
+type requestHeadersKey struct{}
+
+// bypassCacheKey is the context key under which WithBypassCache stores its flag.
+type bypassCacheKey struct{}
+
+// WithRequestID returns a copy of ctx carrying the given request ID. Requests made with
+// the returned context are sent with an X-Request-ID header set to id, which allows
+// correlating them with the caller's own logs. An empty id removes the header.
+//
+// Requests made with a request ID are never coalesced with concurrent requests for the
+// same resource, so each one is sent with its own X-Request-ID header. A cached response
+// is still returned without sending a request.
+//
+// Example:
+//
+//	ctx := genshin.WithRequestID(context.Background(), "req-42")
+//	profile, err := client.GetProfile(ctx, "618285856")
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored in ctx by WithRequestID, or an
+// empty string and false if there is none.
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	if !ok || id == "" {
+		return "", false
+	}
+	return id, true
+}
+
+// WithRequestHeaders returns a copy of ctx carrying additional headers for the requests
+// made with it. They are added to the headers set with the WithHeaders option and take
+// precedence over them if both set the same header. Calling WithRequestHeaders on a
+// context that already carries headers adds to them.
+//
+// The User-Agent header cannot be set this way; it is always taken from WithUserAgent.
+// The X-Request-ID header set through WithRequestID takes precedence as well.
+//
+// Like requests made with a request ID, requests made with the returned context are
+// never coalesced with concurrent requests for the same resource.
+//
+// Example:
+//
+//	ctx := genshin.WithRequestHeaders(ctx, map[string]string{"X-Api-Key": key})
+//	profile, err := client.GetProfile(ctx, "618285856")
+func WithRequestHeaders(ctx context.Context, headers map[string]string) context.Context {
+	merged := RequestHeadersFromContext(ctx).Clone()
+	if merged == nil {
+		merged = make(http.Header, len(headers))
+	}
+	for key, value := range headers {
+		merged.Set(key, value)
+	}
+	return context.WithValue(ctx, requestHeadersKey{}, merged)
+}
+
+// RequestHeadersFromContext returns the headers stored in ctx by WithRequestHeaders, or
+// nil if there are none. The returned headers must not be modified.
+func RequestHeadersFromContext(ctx context.Context) http.Header {
+	headers, _ := ctx.Value(requestHeadersKey{}).(http.Header)
+	return headers
+}
+
+// WithBypassCache returns a copy of ctx that makes requests skip the cache lookup, so
+// the data is always fetched from the API. The fresh response is still written to the
+// cache, replacing the stale entry for later requests. It has no effect on a client
+// without a cache.
+//
+// Example:
+//
+//	// The user asked to refresh their showcase
+//	profile, err := client.GetProfile(genshin.WithBypassCache(ctx), "618285856")
+func WithBypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+// BypassCacheFromContext reports whether ctx was created with WithBypassCache.
+func BypassCacheFromContext(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(bool)
+	return bypass
+}
@@ -1,6 +1,10 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrInvalidUIDFormat   = errors.New("invalid UID format")
@@ -9,4 +13,84 @@ var (
 	ErrServerError        = errors.New("server error")
 	ErrServiceUnavailable = errors.New("service unavailable")
 	ErrRateLimited        = errors.New("rate limited")
+	ErrNoOwner            = errors.New("no enka owner for UID")
+	ErrTruncatedResponse  = errors.New("truncated response body")
+	ErrResponseTooLarge   = errors.New("response body too large")
+	ErrNoContent          = errors.New("no content")
+	ErrUnexpectedStatus   = errors.New("unexpected status")
+
+	ErrInvalidUsername           = errors.New("invalid username")
+	ErrUserNotFound              = errors.New("user not found")
+	ErrHoyoAccountNotFound       = errors.New("hoyo account not found")
+	ErrHoyoAccountBuildsNotFound = errors.New("no builds found for hoyo account")
+	ErrInvalidHoyoHash           = errors.New("invalid hoyo_hash")
+
+	ErrUserAgentRequired = errors.New("user agent is required")
+	ErrInvalidUserAgent  = errors.New("invalid user agent")
+	ErrInvalidLanguage   = errors.New("unsupported language")
+
+	// ErrStaleData is returned along with a stale cached value when a request fails and
+	// the client was created with WithServeStaleOnError. The value is valid but may be
+	// out of date; the error also wraps the failure of the request.
+	ErrStaleData = errors.New("serving stale data")
+
+	// ErrCacheTypeMismatch is reported to the handler set with WithCacheErrorHandler when
+	// a cached value does not have the type expected by the client, e.g. because it was
+	// stored by another version of the library. The value is ignored and refetched.
+	ErrCacheTypeMismatch = errors.New("cached value has an unexpected type")
+
+	// ErrProfileNotCachedYet wraps ErrPlayerNotFound, so errors.Is(err, ErrPlayerNotFound)
+	// remains true for callers that do not need to distinguish the two cases.
+	ErrProfileNotCachedYet = fmt.Errorf("profile not cached yet: %w", ErrPlayerNotFound)
 )
+
+// APIError is returned for a failed request when the API provided more details than the
+// sentinel error can hold, such as the delay requested by a Retry-After header or the
+// expected end of a maintenance. It wraps the sentinel error, so
+// errors.Is(err, ErrRateLimited) still reports true for it; use errors.As to read the
+// details.
+type APIError struct {
+	StatusCode int           // HTTP status code of the last response
+	RetryAfter time.Duration // Delay requested by the last Retry-After header
+	Err        error         // Sentinel error describing the failure, e.g. ErrRateLimited
+
+	// MaintenanceUntil is the time at which the maintenance is expected to end, for
+	// ErrServerMaintenance. It is zero if the API did not provide it.
+	MaintenanceUntil time.Time
+}
+
+// Error returns the message of the wrapped error along with the requested delay, the
+// expected end of the maintenance if known, or the status code for ErrUnexpectedStatus.
+func (e *APIError) Error() string {
+	if e.Err == ErrUnexpectedStatus {
+		return fmt.Sprintf("%v: %d", e.Err, e.StatusCode)
+	}
+	if !e.MaintenanceUntil.IsZero() {
+		return fmt.Sprintf("%v: expected to end at %v", e.Err, e.MaintenanceUntil.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%v: retry after %v", e.Err, e.RetryAfter)
+}
+
+// Unwrap returns the wrapped sentinel error.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether any error in err's tree matches target. It is a shorthand for the
+// standard library errors.Is, which is shadowed by this package's name.
+func Is(err, target error) bool {
+	return errors.Is(err, target)
+}
+
+// As finds the first error in err's tree that matches target, and if one is found, sets
+// target to that error value and returns true. It is a shorthand for the standard
+// library errors.As.
+func As(err error, target any) bool {
+	return errors.As(err, target)
+}
+
+// Join returns an error that wraps the given errors, discarding nil ones. It returns nil
+// if every error is nil. It is a shorthand for the standard library errors.Join.
+func Join(errs ...error) error {
+	return errors.Join(errs...)
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"container/list"
+	"sync"
+)
+
+// DefaultMaxETags is the maximum number of responses kept by the ETagStore of
+// WithConditionalRequests.
+const DefaultMaxETags = 1000
+
+// ETagStore keeps the ETag and body of the last successful response for each URL, so
+// that subsequent requests can be sent with an If-None-Match header. When the API
+// answers with 304 Not Modified, the stored body is used instead of downloading it
+// again.
+//
+// The store holds up to a fixed number of entries. When a response is stored in a full
+// store, the entry of the least recently used URL is evicted, so a client polling many
+// UIDs does not keep every body it has ever received. An evicted URL is simply fetched
+// without If-None-Match next time. An ETagStore is safe for concurrent use.
+type ETagStore struct {
+	mu         sync.Mutex
+	maxEntries int
+	ll         *list.List               // Entries, most recently used first
+	entries    map[string]*list.Element // Maps URLs to their element in ll
+}
+
+// etagEntry is the stored ETag and body of a response.
+type etagEntry struct {
+	url  string
+	etag string
+	body []byte
+}
+
+// NewETagStore creates an empty ETagStore holding up to maxEntries entries. If
+// maxEntries is zero or less, DefaultMaxETags is used.
+func NewETagStore(maxEntries int) *ETagStore {
+	if maxEntries <= 0 {
+		maxEntries = DefaultMaxETags
+	}
+	return &ETagStore{
+		maxEntries: maxEntries,
+		ll:         list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the ETag and body stored for url, or false if there is none.
+func (s *ETagStore) Get(url string) (etag string, body []byte, ok bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	elem, ok := s.entries[url]
+	if !ok {
+		return "", nil, false
+	}
+	s.ll.MoveToFront(elem)
+	entry := elem.Value.(*etagEntry)
+	return entry.etag, entry.body, true
+}
+
+// Set stores the ETag and body of a response for url, evicting the least recently used
+// entry if the store is full. An empty etag removes the entry, since a response without
+// an ETag cannot be revalidated.
+func (s *ETagStore) Set(url, etag string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[url]; ok {
+		if etag == "" {
+			s.ll.Remove(elem)
+			delete(s.entries, url)
+			return
+		}
+		elem.Value = &etagEntry{url: url, etag: etag, body: body}
+		s.ll.MoveToFront(elem)
+		return
+	}
+	if etag == "" {
+		return
+	}
+
+	s.entries[url] = s.ll.PushFront(&etagEntry{url: url, etag: etag, body: body})
+	if s.ll.Len() > s.maxEntries {
+		oldest := s.ll.Back()
+		s.ll.Remove(oldest)
+		delete(s.entries, oldest.Value.(*etagEntry).url)
+	}
+}
+
+// Len returns the number of entries in the store.
+func (s *ETagStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ll.Len()
+}
@@ -0,0 +1,32 @@
+package core
+
+import "testing"
+
+// TestETagStoreEviction checks that the least recently used URL is evicted from a full store.
+func TestETagStoreEviction(t *testing.T) {
+	s := NewETagStore(2)
+	s.Set("a", `"1"`, []byte("a"))
+	s.Set("b", `"2"`, []byte("b"))
+	s.Get("a")
+	s.Set("c", `"3"`, []byte("c"))
+
+	if _, _, ok := s.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	for _, url := range []string{"a", "c"} {
+		if _, body, ok := s.Get(url); !ok || string(body) != url {
+			t.Errorf("Get(%q) = %q, %v, want %q, true", url, body, ok, url)
+		}
+	}
+	if n := s.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+
+	s.Set("a", "", nil)
+	if _, _, ok := s.Get("a"); ok {
+		t.Errorf("expected a to be removed by an empty ETag")
+	}
+	if n := s.Len(); n != 1 {
+		t.Errorf("Len() = %d, want 1", n)
+	}
+}
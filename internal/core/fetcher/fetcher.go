@@ -5,41 +5,46 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strconv"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
 	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
 )
 
-const (
-	maxRetries        = 3               // maxRetries defines the maximum number of retry attempts for failed requests
-	defaultRetryDelay = 5 * time.Second // defaultRetryDelay is the default delay between retry attempts
-)
-
 // Fetcher is a generic HTTP client that handles request retries and error handling.
 // The type parameter T specifies the type to unmarshal the JSON response into.
 type Fetcher[T any] struct {
-	client    *http.Client
-	userAgent string
+	client *core.Client
 }
 
-// NewFetcher creates a new Fetcher instance with the specified HTTP client and user agent.
-// The HTTP client should be configured with appropriate timeouts and transport settings.
-// The user agent string will be included in all requests.
-func NewFetcher[T any](client *http.Client, userAgent string) *Fetcher[T] {
+// NewFetcher creates a new Fetcher instance that sends requests using the settings of
+// the given core.Client: its HTTP client, User-Agent and retry configuration. The
+// settings are read on every request, so changes made to the client after the Fetcher
+// is created are taken into account.
+func NewFetcher[T any](client *core.Client) *Fetcher[T] {
 	return &Fetcher[T]{
-		client:    client,
-		userAgent: userAgent,
+		client: client,
 	}
 }
 
-// FetchWithRetry executes an HTTP GET request to the specified URL with retry logic for transient errors.
-// It handles:
-// - Request timeouts and cancellation via the provided context.
-// - Automatic retries for server errors (500, 503) and rate limiting (429).
-// - Rate limiting by respecting the Retry-After header if present.
-// - Specific error mapping for common HTTP status codes (400, 404, 424, 500, 503).
+// FetchWithRetry executes an HTTP GET request to the specified URL with retry logic for transient errors
+// and unmarshals the response body into T.
+//
+// The request itself, including retries and status code mapping, is performed like
+// FetchRaw; see its documentation for the details of the retry and error handling behavior.
+//
+// A body that ends before the JSON value is complete is usually caused by a connection
+// reset mid-stream, so the request is sent again like a transient error: the attempt
+// counts toward Retry.MaxAttempts, and the retry is subject to the client's RetryBudget.
+// Truncated bodies are not stored for conditional requests. Other decode errors, such as
+// a type mismatch between the response and T, are not transient and are returned
+// immediately.
 //
 // Parameters:
 //   - ctx: Context for controlling request timeout and cancellation.
@@ -47,91 +52,453 @@ func NewFetcher[T any](client *http.Client, userAgent string) *Fetcher[T] {
 //
 // Returns:
 //   - *T: A pointer to the unmarshaled response body of type T on success.
+//   - error: An error if the request fails after all retries, encounters a non-retryable error,
+//     or the response body cannot be decoded into T. If every attempt returned a truncated
+//     body, the error wraps errors.ErrTruncatedResponse.
+func (f *Fetcher[T]) FetchWithRetry(ctx context.Context, url string) (*T, error) {
+	var result T
+	_, _, err := f.fetch(ctx, url, false, func(body []byte) error {
+		var zero T
+		result = zero
+		return json.Unmarshal(body, &result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// FetchRaw executes an HTTP GET request to the specified URL with retry logic for transient errors
+// and returns the undecoded response body.
+// It handles:
+//   - Request timeouts and cancellation via the provided context. No request is sent if
+//     the context is already done.
+//   - A per-attempt timeout if the client has a RequestTimeout, or a FallbackTimeout when
+//     nothing else bounds the request.
+//   - Automatic retries for server errors (500, 503) and rate limiting (429).
+//   - Rate limiting by respecting the Retry-After header if present. If it asks to wait
+//     longer than Retry.MaxRetryAfter, the error of the response is returned immediately.
+//   - Client-side rate limiting by waiting on the client's RateLimiter, if set, before
+//     each attempt, and on a free slot if the client has a MaxConcurrency.
+//   - Specific error mapping for common HTTP status codes (204, 400, 404, 424, 500, 503).
+//   - Additional headers set with core.WithHeaders and core.WithRequestHeaders; the
+//     latter take precedence. The User-Agent header is always the client's UserAgent.
+//   - An X-Request-ID header if a request ID was attached to ctx with core.WithRequestID.
+//   - Conditional requests if the client has an ETags store: the request is sent with
+//     the If-None-Match header of the last response for url, and on 304 Not Modified the
+//     stored body is returned.
+//
+// Parameters:
+//   - ctx: Context for controlling request timeout and cancellation.
+//   - url: The URL to fetch the resource from.
+//
+// Returns:
+//   - json.RawMessage: The raw response body on success.
 //   - error: An error if the request fails after all retries or encounters a non-retryable error.
 //
 // Possible errors:
+//   - The error returned by core.Client.Err if the client configuration is invalid
+//   - errors.ErrNoContent: For 204 No Content, which has no body to decode
 //   - errors.ErrInvalidUIDFormat: For 400 Bad Request
 //   - errors.ErrPlayerNotFound: For 404 Not Found
-//   - errors.ErrServerMaintenance: For 424 Failed Dependency
-//   - errors.ErrServerError: For 500 Internal Server Error (if received outside retries)
-//   - errors.ErrServiceUnavailable: For 503 Service Unavailable (if received outside retries)
-//   - errors.ErrRateLimited: When retries are exhausted due to transient errors (429, 500, 503)
+//   - errors.ErrProfileNotCachedYet: For 404 Not Found whose body indicates that the
+//     account exists but has not been fetched from the game yet (see notFoundError)
+//   - errors.ErrServerMaintenance: For 424 Failed Dependency, or when retries are
+//     exhausted on it if the client has RetryOnMaintenance set. If the API sent a Retry-After
+//     header, it is wrapped in an *errors.APIError whose MaintenanceUntil field holds the
+//     expected end of the maintenance.
+//   - errors.ErrServerError: When retries are exhausted on 500 Internal Server Error
+//   - errors.ErrServiceUnavailable: When retries are exhausted on 503 Service Unavailable
+//   - errors.ErrRateLimited: When retries are exhausted on 429 Too Many Requests.
+//     For 429 and 503, if the API sent a Retry-After header, the error is wrapped in an
+//     *errors.APIError whose RetryAfter field holds the last requested delay.
+//   - errors.ErrResponseTooLarge: If the response body exceeds the client's MaxResponseSize
+//   - An *errors.APIError wrapping errors.ErrUnexpectedStatus for other statuses
 //
-// The function attempts up to maxRetries times for transient errors (429, 500, 503).
-// If retries are exhausted, it returns errors.ErrRateLimited.
+// The function attempts up to Retry.MaxAttempts times for transient errors (429, 500, 503,
+// and 424 if the client has RetryOnMaintenance set). Temporary network errors, such as timeouts,
+// temporary DNS failures and reset connections, are retried the same way; if they persist,
+// the last one is returned.
+// If retries are exhausted, including when MaxAttempts is 1, it returns the error of the
+// last response. The same happens without waiting if the client has a RetryBudget that
+// does not allow another retry.
 // For other error status codes, it returns immediately with the corresponding error.
-func (f *Fetcher[T]) FetchWithRetry(ctx context.Context, url string) (*T, error) {
-	for attempt := range maxRetries {
-		req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
-		if err != nil {
-			return nil, err
-		}
+func (f *Fetcher[T]) FetchRaw(ctx context.Context, url string) (json.RawMessage, error) {
+	body, _, err := f.fetch(ctx, url, false, nil)
+	return body, err
+}
 
-		req.Header.Set("User-Agent", f.userAgent)
+// FetchStream is like FetchRaw, but returns the body of a successful response without
+// reading it, so that it can be decoded as it is received instead of being held in
+// memory in full. The caller must close the body.
+//
+// Reading the body fails with errors.ErrResponseTooLarge once it exceeds the client's
+// MaxResponseSize. The attempt keeps its slot of the client's MaxConcurrency and its
+// RequestTimeout or FallbackTimeout until the body is closed. Conditional requests are
+// not used, since the body is not stored.
+func (f *Fetcher[T]) FetchStream(ctx context.Context, url string) (io.ReadCloser, error) {
+	_, stream, err := f.fetch(ctx, url, true, nil)
+	return stream, err
+}
 
-		resp, err := f.client.Do(req)
-		if err != nil {
-			return nil, err
+// fetch implements FetchWithRetry, FetchRaw and FetchStream. If stream is true, the body
+// of a successful response is returned unread as the second result; otherwise it is read
+// in full and returned as the first result.
+//
+// If decode is not nil, it is called with the body of a successful response, and its
+// error is returned. If the body was truncated (see isTruncated), the request is retried
+// like a transient error instead, and errors.ErrTruncatedResponse is returned once no
+// attempt is left.
+func (f *Fetcher[T]) fetch(ctx context.Context, url string, stream bool, decode func(body []byte) error) (json.RawMessage, io.ReadCloser, error) {
+	if err := f.client.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	// At least one attempt is made even if Retry was changed after the client was created
+	maxAttempts := max(f.client.Retry.MaxAttempts, 1)
+
+	var etag string
+	var storedBody []byte
+	if f.client.ETags != nil && !stream {
+		etag, storedBody, _ = f.client.ETags.Get(url)
+	}
+
+	var lastStatus int
+	var retryAfter time.Duration // Last delay requested by a Retry-After header
+	var hasRetryAfter bool
+	var maintenanceErr error // Error describing the last 424 response
+	for attempt := range maxAttempts {
+		// Do not send a request if the context is already canceled or expired
+		if err := ctx.Err(); err != nil {
+			return nil, nil, err
+		}
+
+		if f.client.RateLimiter != nil {
+			if err := f.client.RateLimiter.Wait(ctx); err != nil {
+				return nil, nil, err
+			}
 		}
-		defer resp.Body.Close()
 
-		body, err := io.ReadAll(resp.Body)
+		resp, body, err := f.do(ctx, url, etag, stream)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
+			// Retry transient network errors, such as timeouts or reset connections, unless
+			// the caller's context is done
+			if attempt < maxAttempts-1 && ctx.Err() == nil && isTemporaryNetworkError(err) {
+				if budget := f.client.RetryBudget; budget != nil && !budget.Allow() {
+					return nil, nil, err
+				}
+				select {
+				case <-f.clock().After(f.client.Retry.DefaultDelay):
+					continue
+				case <-ctx.Done():
+					return nil, nil, ctx.Err()
+				}
+			}
+			return nil, nil, err
 		}
 
 		if resp.StatusCode == http.StatusOK {
-			var result T
+			if stream {
+				return nil, resp.Body, nil
+			}
+			if decode != nil {
+				if err := decode(body); err != nil {
+					if !isTruncated(body, err) {
+						return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+					}
+					truncatedErr := fmt.Errorf("failed to decode response: %w: %w", errors.ErrTruncatedResponse, err)
+					if attempt == maxAttempts-1 {
+						return nil, nil, truncatedErr
+					}
+					if budget := f.client.RetryBudget; budget != nil && !budget.Allow() {
+						return nil, nil, truncatedErr
+					}
+					// Wait before requesting the truncated body again or exit if context is canceled
+					select {
+					case <-f.clock().After(f.client.Retry.DefaultDelay):
+						continue
+					case <-ctx.Done():
+						return nil, nil, ctx.Err()
+					}
+				}
+			}
+			if f.client.ETags != nil {
+				f.client.ETags.Set(url, resp.Header.Get("ETag"), body)
+			}
+			return json.RawMessage(body), nil, nil
+		}
 
-			err = json.Unmarshal(body, &result)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decode profile: %w", err)
+		if resp.StatusCode == http.StatusNotModified && etag != "" {
+			if decode != nil {
+				if err := decode(storedBody); err != nil {
+					return nil, nil, fmt.Errorf("failed to decode response: %w", err)
+				}
 			}
+			return json.RawMessage(storedBody), nil, nil
+		}
 
-			return &result, nil
+		lastStatus = resp.StatusCode
+		if resp.StatusCode == http.StatusFailedDependency {
+			maintenanceErr = maintenanceError(resp.Header.Get("Retry-After"), f.clock().Now())
 		}
 
-		// Check for retryable status codes: 429 (Too Many Requests), 500 (Internal Server Error), 503 (Service Unavailable)
+		// Check for retryable status codes: 429 (Too Many Requests), 500 (Internal Server Error), 503 (Service Unavailable),
+		// and 424 (Failed Dependency) if the client retries on maintenance
 		if resp.StatusCode == http.StatusTooManyRequests ||
 			resp.StatusCode == http.StatusInternalServerError ||
-			resp.StatusCode == http.StatusServiceUnavailable {
-			// If not the last attempt, calculate delay and retry
-			if attempt < maxRetries-1 {
-				delay := defaultRetryDelay
-				// For 429 and 503, attempt to parse Retry-After header for custom delay
-				if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
-					retryAfter := resp.Header.Get("Retry-After")
-					if retryAfter != "" {
-						delay = parseRetryAfter(retryAfter)
-					}
+			resp.StatusCode == http.StatusServiceUnavailable ||
+			(resp.StatusCode == http.StatusFailedDependency && f.client.RetryOnMaintenance) {
+			delay := f.client.Retry.DefaultDelay
+			// For 429 and 503, attempt to parse Retry-After header for custom delay
+			if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+				if header := resp.Header.Get("Retry-After"); header != "" {
+					delay = parseRetryAfter(header, f.client.Retry.DefaultDelay, f.clock().Now())
+					retryAfter, hasRetryAfter = delay, true
+				}
+				// Do not block for an unreasonably long time requested by the API
+				if maxDelay := f.client.Retry.MaxRetryAfter; maxDelay > 0 && delay > maxDelay {
+					return nil, nil, retriesExhaustedError(lastStatus, retryAfter, hasRetryAfter, maintenanceErr)
+				}
+			}
+			// If not the last attempt, wait for the delay and retry
+			if attempt < maxAttempts-1 {
+				// Fail fast if the retries shared with other requests are exhausted
+				if budget := f.client.RetryBudget; budget != nil && !budget.Allow() {
+					return nil, nil, retriesExhaustedError(lastStatus, retryAfter, hasRetryAfter, maintenanceErr)
 				}
 				// Wait for the calculated delay or exit if context is canceled
 				select {
-				case <-time.After(delay):
+				case <-f.clock().After(delay):
 					continue
 				case <-ctx.Done():
-					return nil, ctx.Err()
+					return nil, nil, ctx.Err()
 				}
 			}
 		} else {
 			switch resp.StatusCode {
+			case 204:
+				return nil, nil, errors.ErrNoContent
 			case 400:
-				return nil, errors.ErrInvalidUIDFormat
+				return nil, nil, errors.ErrInvalidUIDFormat
 			case 404:
-				return nil, errors.ErrPlayerNotFound
+				return nil, nil, notFoundError(body)
 			case 424:
-				return nil, errors.ErrServerMaintenance
-			case 500:
-				return nil, errors.ErrServerError
-			case 503:
-				return nil, errors.ErrServiceUnavailable
+				return nil, nil, maintenanceErr
 			default:
-				return nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+				return nil, nil, &errors.APIError{StatusCode: resp.StatusCode, Err: errors.ErrUnexpectedStatus}
 			}
 		}
 	}
 
-	return nil, errors.ErrRateLimited
+	return nil, nil, retriesExhaustedError(lastStatus, retryAfter, hasRetryAfter, maintenanceErr)
+}
+
+// retriesExhaustedError returns the error of a request that cannot be retried anymore
+// after failing with the transient status: maintenanceErr for 424, errors.ErrServerError
+// for 500, errors.ErrServiceUnavailable for 503 and errors.ErrRateLimited for 429 (see
+// retryAfterError).
+func retriesExhaustedError(status int, retryAfter time.Duration, hasRetryAfter bool, maintenanceErr error) error {
+	switch status {
+	case http.StatusFailedDependency:
+		return maintenanceErr
+	case http.StatusInternalServerError:
+		return errors.ErrServerError
+	case http.StatusServiceUnavailable:
+		return retryAfterError(errors.ErrServiceUnavailable, status, retryAfter, hasRetryAfter)
+	default:
+		return retryAfterError(errors.ErrRateLimited, status, retryAfter, hasRetryAfter)
+	}
+}
+
+// isTemporaryNetworkError reports whether err, returned while sending a request, is a
+// network failure that may not happen again: a timeout, including one caused by the
+// client's RequestTimeout, a temporary DNS failure or a connection reset by the peer.
+func isTemporaryNetworkError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET)
+}
+
+// retryAfterError returns err, such as errors.ErrRateLimited, for a request that failed
+// with status. If the API sent a Retry-After header, the error is an *errors.APIError
+// wrapping err and carrying the requested delay, so callers can wait for exactly that
+// long before trying again.
+func retryAfterError(err error, status int, retryAfter time.Duration, hasRetryAfter bool) error {
+	if !hasRetryAfter {
+		return err
+	}
+	return &errors.APIError{StatusCode: status, RetryAfter: retryAfter, Err: err}
+}
+
+// maintenanceError returns errors.ErrServerMaintenance for a 424 response. If the API
+// sent a Retry-After header with it, the error is an *errors.APIError whose
+// MaintenanceUntil holds the expected end of the maintenance, so callers can sleep until
+// then instead of polling.
+func maintenanceError(retryAfterHeader string, now time.Time) error {
+	delay, ok := parseRetryAfterHeader(retryAfterHeader, now)
+	if !ok {
+		return errors.ErrServerMaintenance
+	}
+	return &errors.APIError{
+		StatusCode:       http.StatusFailedDependency,
+		RetryAfter:       delay,
+		MaintenanceUntil: now.Add(delay),
+		Err:              errors.ErrServerMaintenance,
+	}
+}
+
+// clock returns the clock of the client, falling back to core.DefaultClock for a client
+// that was not created with core.New.
+func (f *Fetcher[T]) clock() core.Clock {
+	if f.client.Clock == nil {
+		return core.DefaultClock
+	}
+	return f.client.Clock
+}
+
+// do sends a single GET request to url and returns the response along with its body,
+// which is read in full and closed. A body larger than the client's MaxResponseSize
+// results in errors.ErrResponseTooLarge. If etag is not empty, it is sent in the
+// If-None-Match header. The request waits for a slot of the client's MaxConcurrency, if
+// set, and is then sent with the context returned by the client's AttemptContext, which
+// applies its RequestTimeout or FallbackTimeout.
+//
+// If stream is true and the response is 200 OK, its body is not read: resp.Body is
+// replaced by a streamBody, which holds the slot and the attempt context until closed.
+func (f *Fetcher[T]) do(ctx context.Context, url, etag string, stream bool) (*http.Response, []byte, error) {
+	release, err := f.client.AcquireSlot(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := f.client.AttemptContext(ctx)
+	done := func() {
+		cancel()
+		release()
+	}
+
+	req, err := core.NewRequest(ctx, f.client, http.MethodGet, url)
+	if err != nil {
+		done()
+		return nil, nil, err
+	}
+
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := f.client.Send(req)
+	if err != nil {
+		done()
+		return nil, nil, err
+	}
+
+	// Read one byte past the limit to tell a body of exactly the maximum size from a larger one
+	maxSize := f.client.MaxResponseSize
+	if maxSize <= 0 {
+		maxSize = core.DefaultMaxResponseSize
+	}
+
+	if stream && resp.StatusCode == http.StatusOK {
+		resp.Body = &streamBody{
+			body:    resp.Body,
+			r:       io.LimitReader(resp.Body, maxSize+1),
+			maxSize: maxSize,
+			done:    done,
+		}
+		return resp, nil, nil
+	}
+
+	defer done()
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	if int64(len(body)) > maxSize {
+		return nil, nil, errors.ErrResponseTooLarge
+	}
+
+	return resp, body, nil
+}
+
+// streamBody is the body of a response returned by FetchStream. It fails with
+// errors.ErrResponseTooLarge once more than maxSize bytes are read, and releases the
+// resources of the attempt when closed.
+type streamBody struct {
+	body    io.ReadCloser // Body of the response
+	r       io.Reader     // body limited to maxSize+1 bytes
+	read    int64         // Number of bytes read so far
+	maxSize int64
+	done    func() // Releases the slot and cancels the attempt context
+	once    sync.Once
+}
+
+func (b *streamBody) Read(p []byte) (int, error) {
+	// Do not read past the limit again once it has been exceeded
+	if b.read > b.maxSize {
+		return 0, errors.ErrResponseTooLarge
+	}
+	n, err := b.r.Read(p)
+	b.read += int64(n)
+	if b.read > b.maxSize {
+		// r is limited to maxSize+1 bytes, so only the last byte read is over the limit
+		return n - int(b.read-b.maxSize), errors.ErrResponseTooLarge
+	}
+	return n, err
+}
+
+func (b *streamBody) Close() error {
+	err := b.body.Close()
+	b.once.Do(b.done)
+	return err
+}
+
+// notFoundError maps the body of a 404 response to an error.
+//
+// The API returns a bare 404 for accounts that do not exist. When an account exists but
+// its data has not been fetched from the game yet, the 404 comes with a JSON body that
+// either carries a positive ttl or a message asking to try again later. In that case
+// errors.ErrProfileNotCachedYet is returned; otherwise errors.ErrPlayerNotFound.
+func notFoundError(body []byte) error {
+	var payload struct {
+		TTL     int    `json:"ttl"`
+		Message string `json:"message"`
+		Detail  string `json:"detail"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return errors.ErrPlayerNotFound
+	}
+
+	if payload.TTL > 0 {
+		return errors.ErrProfileNotCachedYet
+	}
+
+	message := strings.ToLower(payload.Message + " " + payload.Detail)
+	for _, hint := range []string{"try again", "later", "not cached", "not yet"} {
+		if strings.Contains(message, hint) {
+			return errors.ErrProfileNotCachedYet
+		}
+	}
+
+	return errors.ErrPlayerNotFound
+}
+
+// isTruncated reports whether err, returned when decoding body, is caused by the body
+// ending before the JSON value is complete, as opposed to malformed or mismatched data.
+func isTruncated(body []byte, err error) bool {
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return false
+	}
+	return syntaxErr.Offset >= int64(len(body))
 }
 
 // parseRetryAfter parses the Retry-After header value into a time.Duration.
@@ -139,19 +506,30 @@ func (f *Fetcher[T]) FetchWithRetry(ctx context.Context, url string) (*T, error)
 //   - Integer values (seconds)
 //   - HTTP date strings (RFC 1123 format)
 //
-// If parsing fails or the date is in the past, it returns the defaultRetryDelay.
-func parseRetryAfter(retryAfter string) time.Duration {
+// Dates are interpreted relative to now. If parsing fails, it returns defaultDelay. If the
+// date is in the past, it returns 0.
+func parseRetryAfter(retryAfter string, defaultDelay time.Duration, now time.Time) time.Duration {
+	if delay, ok := parseRetryAfterHeader(retryAfter, now); ok {
+		return delay
+	}
+	return defaultDelay // Default if parsing fails
+}
+
+// parseRetryAfterHeader parses a Retry-After header in either of its formats, a number of
+// seconds or an HTTP date, and returns the delay it requests relative to now. It returns
+// false if the header is empty or invalid.
+func parseRetryAfterHeader(retryAfter string, now time.Time) (time.Duration, bool) {
 	if seconds, err := strconv.Atoi(retryAfter); err == nil {
-		return time.Duration(seconds) * time.Second
+		return time.Duration(seconds) * time.Second, true
 	}
 
 	if date, err := time.Parse(time.RFC1123, retryAfter); err == nil {
-		delay := time.Until(date)
+		delay := date.Sub(now)
 		if delay < 0 {
-			return 0 // Retry immediately if the date is in the past
+			return 0, true // Retry immediately if the date is in the past
 		}
-		return delay
+		return delay, true
 	}
 
-	return defaultRetryDelay // Default if parsing fails
+	return 0, false
 }
@@ -0,0 +1,712 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core"
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// TestFetchRawCanceledContext checks that no request is sent when the context is already canceled.
+func TestFetchRawCanceledContext(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	f := NewFetcher[map[string]any](core.New())
+	_, err := f.FetchRaw(ctx, server.URL)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
+
+// countingLimiter is a RateLimiter that counts how many times it was waited on.
+type countingLimiter struct {
+	waits atomic.Int32
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.waits.Add(1)
+	return nil
+}
+
+// TestFetchRawRateLimiter checks that the rate limiter is waited on before the request.
+func TestFetchRawRateLimiter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	limiter := &countingLimiter{}
+	f := NewFetcher[map[string]any](core.New(core.WithRateLimiter(limiter)))
+	if _, err := f.FetchRaw(context.Background(), server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := limiter.waits.Load(); n != 1 {
+		t.Errorf("expected 1 wait, got %d", n)
+	}
+}
+
+// TestFetchRawNotFound checks that 404 responses are mapped according to their body.
+func TestFetchRawNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want error
+	}{
+		{"bare", ``, errors.ErrPlayerNotFound},
+		{"not json", `Not Found`, errors.ErrPlayerNotFound},
+		{"ttl", `{"ttl":60}`, errors.ErrProfileNotCachedYet},
+		{"message", `{"message":"Try again later"}`, errors.ErrProfileNotCachedYet},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			f := NewFetcher[map[string]any](core.New())
+			_, err := f.FetchRaw(context.Background(), server.URL)
+			if err != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, err)
+			}
+			if !errors.Is(err, errors.ErrPlayerNotFound) {
+				t.Errorf("expected error to match ErrPlayerNotFound, got %v", err)
+			}
+		})
+	}
+}
+
+// TestFetchRawRequestTimeout checks that a request exceeding the request timeout fails.
+func TestFetchRawRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	f := NewFetcher[map[string]any](core.New(core.WithRequestTimeout(20 * time.Millisecond)))
+	_, err := f.FetchRaw(context.Background(), server.URL)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+// TestFetchRawConditionalRequest checks that the stored body is returned on 304 Not Modified.
+func TestFetchRawConditionalRequest(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"ttl":60}`))
+	}))
+	defer server.Close()
+
+	f := NewFetcher[map[string]any](core.New(core.WithConditionalRequests()))
+	for i := range 2 {
+		body, err := f.FetchRaw(context.Background(), server.URL)
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i, err)
+		}
+		if string(body) != `{"ttl":60}` {
+			t.Errorf("request %d: unexpected body %s", i, body)
+		}
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+// TestFetchWithRetryTruncatedBody checks that a truncated body is requested again, while
+// a body that does not match the target type is not.
+func TestFetchWithRetryTruncatedBody(t *testing.T) {
+	bodies := []string{`{"ttl":6`, `{"ttl":60}`}
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		w.Write([]byte(bodies[min(int(n), len(bodies))-1]))
+	}))
+	defer server.Close()
+
+	client := core.New(core.WithRetryConfig(core.RetryConfig{MaxAttempts: 3, DefaultDelay: time.Millisecond}))
+
+	result, err := NewFetcher[struct{ TTL int }](client).FetchWithRetry(context.Background(), server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.TTL != 60 {
+		t.Errorf("expected TTL 60, got %d", result.TTL)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+
+	requests.Store(0)
+	_, err = NewFetcher[struct{ TTL string }](client).FetchWithRetry(context.Background(), server.URL)
+	if err == nil || errors.Is(err, errors.ErrTruncatedResponse) {
+		t.Errorf("expected a decode error, got %v", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("expected a single request after the truncated one, got %d", n)
+	}
+}
+
+// TestFetchWithRetryTruncatedAttempts checks that truncated bodies and transient statuses
+// share the same attempts and retry budget.
+func TestFetchWithRetryTruncatedAttempts(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1)%2 == 0 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"ttl":6`))
+	}))
+	defer server.Close()
+
+	retry := core.WithRetryConfig(core.RetryConfig{MaxAttempts: 3, DefaultDelay: time.Millisecond})
+
+	tests := []struct {
+		name     string
+		budget   *core.RetryBudget
+		requests int32
+	}{
+		{"no budget", nil, 3},
+		{"exhausted budget", core.NewRetryBudget(0, 0), 1},
+	}
+
+	for _, tt := range tests {
+		requests.Store(0)
+		client := core.New(retry, core.WithRetryBudget(tt.budget))
+		_, err := NewFetcher[struct{ TTL int }](client).FetchWithRetry(context.Background(), server.URL)
+		if !errors.Is(err, errors.ErrTruncatedResponse) {
+			t.Errorf("%s: expected ErrTruncatedResponse, got %v", tt.name, err)
+		}
+		if n := requests.Load(); n != tt.requests {
+			t.Errorf("%s: expected %d requests, got %d", tt.name, tt.requests, n)
+		}
+	}
+}
+
+// TestFetchRawRequestID checks that the request ID of the context is sent as X-Request-ID.
+func TestFetchRawRequestID(t *testing.T) {
+	var header atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header.Store(r.Header.Values("X-Request-ID"))
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	f := NewFetcher[map[string]any](core.New())
+
+	if _, err := f.FetchRaw(core.WithRequestID(context.Background(), "req-42"), server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := header.Load().([]string); len(got) != 1 || got[0] != "req-42" {
+		t.Errorf("expected X-Request-ID req-42, got %v", got)
+	}
+
+	if _, err := f.FetchRaw(context.Background(), server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := header.Load().([]string); len(got) != 0 {
+		t.Errorf("expected no X-Request-ID, got %v", got)
+	}
+}
+
+// TestFetchRawHeaders checks the precedence of client and per-request headers.
+func TestFetchRawHeaders(t *testing.T) {
+	var header atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header.Store(r.Header.Clone())
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := core.New(
+		core.WithUserAgent("test-agent"),
+		core.WithHeaders(map[string]string{"X-Api-Key": "client", "Authorization": "Bearer token", "User-Agent": "other"}),
+	)
+	ctx := core.WithRequestHeaders(context.Background(), map[string]string{"X-Api-Key": "request"})
+
+	if _, err := NewFetcher[map[string]any](client).FetchRaw(ctx, server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := header.Load().(http.Header)
+	for key, want := range map[string]string{"X-Api-Key": "request", "Authorization": "Bearer token", "User-Agent": "test-agent"} {
+		if got.Get(key) != want {
+			t.Errorf("expected %s %q, got %q", key, want, got.Get(key))
+		}
+	}
+}
+
+// TestFetchRawNoRetry checks that error responses are not retried with WithNoRetry and
+// are mapped to the error of their status.
+func TestFetchRawNoRetry(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusTooManyRequests, errors.ErrRateLimited},
+		{http.StatusInternalServerError, errors.ErrServerError},
+		{http.StatusServiceUnavailable, errors.ErrServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			w.WriteHeader(tt.status)
+		}))
+
+		f := NewFetcher[map[string]any](core.New(core.WithNoRetry()))
+		if _, err := f.FetchRaw(context.Background(), server.URL); err != tt.want {
+			t.Errorf("status %d: expected %v, got %v", tt.status, tt.want, err)
+		}
+		if n := requests.Load(); n != 1 {
+			t.Errorf("status %d: expected 1 request, got %d", tt.status, n)
+		}
+
+		server.Close()
+	}
+}
+
+// TestFetchRawMaxRetryAfter checks that a Retry-After above the maximum is not waited for.
+func TestFetchRawMaxRetryAfter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	f := NewFetcher[map[string]any](core.New())
+	start := time.Now()
+	_, err := f.FetchRaw(context.Background(), server.URL)
+	if !errors.Is(err, errors.ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+	var apiErr *errors.APIError
+	if !errors.As(err, &apiErr) || apiErr.RetryAfter != time.Hour || apiErr.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected an APIError with RetryAfter 1h and status 429, got %#v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected an immediate error, took %v", elapsed)
+	}
+}
+
+// TestFetchRawResponseTooLarge checks that a body over the limit is rejected while one at the limit is accepted.
+func TestFetchRawResponseTooLarge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ttl":60}`))
+	}))
+	defer server.Close()
+
+	f := NewFetcher[map[string]any](core.New(core.WithMaxResponseSize(9)))
+	if _, err := f.FetchRaw(context.Background(), server.URL); err != errors.ErrResponseTooLarge {
+		t.Errorf("expected ErrResponseTooLarge, got %v", err)
+	}
+
+	f = NewFetcher[map[string]any](core.New(core.WithMaxResponseSize(10)))
+	if _, err := f.FetchRaw(context.Background(), server.URL); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// TestFetchStream checks that the streamed body is limited to MaxResponseSize and
+// releases its slot of MaxConcurrency when closed.
+func TestFetchStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ttl":60}`))
+	}))
+	defer server.Close()
+
+	for _, tt := range []struct {
+		maxSize int64
+		want    string
+		wantErr error
+	}{
+		{9, `{"ttl":60`, errors.ErrResponseTooLarge},
+		{10, `{"ttl":60}`, nil},
+	} {
+		f := NewFetcher[map[string]any](core.New(core.WithMaxResponseSize(tt.maxSize), core.WithMaxConcurrency(1)))
+		for range 2 {
+			// The second request only gets a slot if the first body released it
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			body, err := f.FetchStream(ctx, server.URL)
+			if err != nil {
+				cancel()
+				t.Fatalf("max size %d: unexpected error: %v", tt.maxSize, err)
+			}
+			data, err := io.ReadAll(body)
+			body.Close()
+			cancel()
+			if string(data) != tt.want || err != tt.wantErr {
+				t.Errorf("max size %d: read %q, %v, want %q, %v", tt.maxSize, data, err, tt.want, tt.wantErr)
+			}
+		}
+	}
+}
+
+// TestStreamBodyReadAfterLimit checks that reading a body again after it exceeded the
+// maximum size returns no bytes instead of a negative count.
+func TestStreamBodyReadAfterLimit(t *testing.T) {
+	data := io.NopCloser(strings.NewReader("0123456789"))
+	body := &streamBody{body: data, r: io.LimitReader(data, 5), maxSize: 4, done: func() {}}
+
+	var read []byte
+	buf := make([]byte, 3)
+	for i := range 4 {
+		n, err := body.Read(buf)
+		if n < 0 || n > len(buf) {
+			t.Fatalf("read %d: invalid count %d", i, n)
+		}
+		read = append(read, buf[:n]...)
+		if i >= 1 && err != errors.ErrResponseTooLarge {
+			t.Errorf("read %d: expected ErrResponseTooLarge, got %v", i, err)
+		}
+	}
+	if string(read) != "0123" {
+		t.Errorf("read %q, want %q", read, "0123")
+	}
+}
+
+// TestFetchRawNoContent checks that 204 No Content results in ErrNoContent instead of a decode error.
+func TestFetchRawNoContent(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	f := NewFetcher[map[string]any](core.New())
+	if _, err := f.FetchWithRetry(context.Background(), server.URL); err != errors.ErrNoContent {
+		t.Errorf("expected ErrNoContent, got %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+// TestFetchRawRetryOnMaintenance checks that 424 is only retried when enabled, and still reported as maintenance.
+func TestFetchRawRetryOnMaintenance(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusFailedDependency)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		opts     []core.Option
+		requests int32
+	}{
+		{"default", nil, 1},
+		{"retry on maintenance", []core.Option{
+			core.WithRetryConfig(core.RetryConfig{MaxAttempts: 3, DefaultDelay: time.Millisecond}),
+			core.WithRetryOnMaintenance(),
+		}, 3},
+		{"before retry config", []core.Option{
+			core.WithRetryOnMaintenance(),
+			core.WithRetryConfig(core.RetryConfig{MaxAttempts: 3, DefaultDelay: time.Millisecond}),
+		}, 3},
+		{"no retry", []core.Option{
+			core.WithRetryOnMaintenance(),
+			core.WithNoRetry(),
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		requests.Store(0)
+		f := NewFetcher[map[string]any](core.New(tt.opts...))
+		if _, err := f.FetchRaw(context.Background(), server.URL); err != errors.ErrServerMaintenance {
+			t.Errorf("%s: expected ErrServerMaintenance, got %v", tt.name, err)
+		}
+		if n := requests.Load(); n != tt.requests {
+			t.Errorf("%s: expected %d requests, got %d", tt.name, tt.requests, n)
+		}
+	}
+}
+
+// TestFetchRawMaintenanceUntil checks that the end of a maintenance announced by Retry-After is reported.
+func TestFetchRawMaintenanceUntil(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("eta") {
+			w.Header().Set("Retry-After", "1800")
+		}
+		w.WriteHeader(http.StatusFailedDependency)
+	}))
+	defer server.Close()
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)}
+	f := NewFetcher[map[string]any](core.New(core.WithClock(clock)))
+
+	_, err := f.FetchRaw(context.Background(), server.URL+"?eta")
+	var apiErr *errors.APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, errors.ErrServerMaintenance) {
+		t.Fatalf("expected an APIError wrapping ErrServerMaintenance, got %v", err)
+	}
+	if want := clock.now.Add(30 * time.Minute); !apiErr.MaintenanceUntil.Equal(want) {
+		t.Errorf("MaintenanceUntil = %v, want %v", apiErr.MaintenanceUntil, want)
+	}
+
+	if _, err := f.FetchRaw(context.Background(), server.URL); err != errors.ErrServerMaintenance {
+		t.Errorf("expected bare ErrServerMaintenance without Retry-After, got %v", err)
+	}
+}
+
+// TestFetchRawRetryBudget checks that clients sharing an exhausted retry budget fail without retrying.
+func TestFetchRawRetryBudget(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	budget := core.NewRetryBudget(2, 0)
+	retry := core.WithRetryConfig(core.RetryConfig{MaxAttempts: 3, DefaultDelay: time.Millisecond})
+
+	tests := []struct {
+		name     string
+		requests int32
+	}{
+		{"first client", 3},
+		{"second client", 1},
+	}
+
+	for _, tt := range tests {
+		requests.Store(0)
+		f := NewFetcher[map[string]any](core.New(retry, core.WithRetryBudget(budget)))
+		if _, err := f.FetchRaw(context.Background(), server.URL); err != errors.ErrServerError {
+			t.Errorf("%s: expected ErrServerError, got %v", tt.name, err)
+		}
+		if n := requests.Load(); n != tt.requests {
+			t.Errorf("%s: expected %d requests, got %d", tt.name, tt.requests, n)
+		}
+	}
+}
+
+// fakeClock is a core.Clock that records the requested delays and returns immediately.
+type fakeClock struct {
+	now    time.Time
+	delays []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.delays = append(c.delays, d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now.Add(d)
+	return ch
+}
+
+// TestFetchRawRetryDelays checks the delays between retries using a fake clock, without waiting for them.
+func TestFetchRawRetryDelays(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	retryAfter := []string{"", clock.now.Add(20 * time.Second).Format(time.RFC1123), ""}
+
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if value := retryAfter[int(n)-1]; value != "" {
+			w.Header().Set("Retry-After", value)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := core.New(
+		core.WithRetryConfig(core.RetryConfig{MaxAttempts: 3, DefaultDelay: 5 * time.Second}),
+		core.WithClock(clock),
+	)
+
+	start := time.Now()
+	if _, err := NewFetcher[map[string]any](client).FetchRaw(context.Background(), server.URL); !errors.Is(err, errors.ErrServiceUnavailable) {
+		t.Errorf("expected ErrServiceUnavailable, got %v", err)
+	}
+
+	want := []time.Duration{5 * time.Second, 20 * time.Second}
+	if !reflect.DeepEqual(clock.delays, want) {
+		t.Errorf("delays = %v, want %v", clock.delays, want)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected no real waiting, took %v", elapsed)
+	}
+}
+
+// timeoutError is a net.Error reporting a timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// TestFetchRawHTTPDoer checks that requests are sent through the HTTPDoer, without a server.
+func TestFetchRawHTTPDoer(t *testing.T) {
+	var statuses []int
+	doer := core.HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		if len(statuses) == 0 {
+			return nil, timeoutError{}
+		}
+		status := statuses[0]
+		statuses = statuses[1:]
+		return &http.Response{
+			StatusCode: status,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(`{"ttl":60}`)),
+			Request:    req,
+		}, nil
+	})
+
+	clock := &fakeClock{}
+	f := NewFetcher[map[string]any](core.New(core.WithHTTPDoer(doer), core.WithClock(clock)))
+
+	statuses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusOK}
+	if _, err := f.FetchRaw(context.Background(), "https://enka.network/api/uid/618285856"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(clock.delays) != 2 {
+		t.Errorf("expected 2 retries, got %d", len(clock.delays))
+	}
+
+	_, err := f.FetchRaw(context.Background(), "https://enka.network/api/uid/618285856")
+	if !core.IsTransient(err) {
+		t.Errorf("expected a transient timeout error, got %v", err)
+	}
+}
+
+// TestFetchRawRetryNetworkError checks that temporary network errors are retried and other errors are not.
+func TestFetchRawRetryNetworkError(t *testing.T) {
+	errs := map[string]error{
+		"timeout":   timeoutError{},
+		"permanent": fmt.Errorf("unsupported protocol scheme"),
+	}
+
+	tests := []struct {
+		name     string
+		wantErr  bool
+		attempts int
+	}{
+		{"timeout", false, 2},
+		{"permanent", true, 1},
+	}
+
+	for _, tt := range tests {
+		attempts := 0
+		doer := core.HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return nil, errs[tt.name]
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     make(http.Header),
+				Body:       io.NopCloser(strings.NewReader(`{}`)),
+				Request:    req,
+			}, nil
+		})
+
+		f := NewFetcher[map[string]any](core.New(core.WithHTTPDoer(doer), core.WithClock(&fakeClock{})))
+		_, err := f.FetchRaw(context.Background(), "https://enka.network/api/uid/618285856")
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+		}
+		if attempts != tt.attempts {
+			t.Errorf("%s: expected %d attempts, got %d", tt.name, tt.attempts, attempts)
+		}
+	}
+}
+
+// TestFetchRawMaxConcurrency checks that no more than MaxConcurrency requests are in flight
+// and that waiting for a slot respects the context.
+func TestFetchRawMaxConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	unblock := make(chan struct{})
+	doer := core.HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		<-unblock
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+			Request:    req,
+		}, nil
+	})
+
+	f := NewFetcher[map[string]any](core.New(core.WithHTTPDoer(doer), core.WithMaxConcurrency(2)))
+
+	var wg sync.WaitGroup
+	for range 6 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := f.FetchRaw(context.Background(), "https://enka.network/api/uid/618285856"); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+
+	// Wait for two requests to hold the slots, then check that a third caller gives up
+	// when its context expires
+	for inFlight.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := f.FetchRaw(ctx, "https://enka.network/api/uid/618285856"); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded while waiting for a slot, got %v", err)
+	}
+
+	close(unblock)
+	wg.Wait()
+	if p := peak.Load(); p != 2 {
+		t.Errorf("peak requests in flight = %d, want 2", p)
+	}
+}
@@ -0,0 +1,47 @@
+package core
+
+import "github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+
+// NotFound is the value cached under NotFoundKey for a resource the API reported as not
+// existing. It has no fields, so any cache can serialize it, e.g. as JSON; only the
+// presence of the entry is meaningful. The game-specific clients register it with
+// cache.Register, so that a cache.FileCache persists it.
+type NotFound struct{}
+
+// NotFoundKey returns the key under which CacheNotFound records that the resource cached
+// under key does not exist, e.g. "notfound_genshin_618285856". It differs from the keys
+// of the responses, so a not found result never replaces a cached response, such as the
+// expired value returned by WithServeStaleOnError.
+func NotFoundKey(key string) string {
+	return "notfound_" + key
+}
+
+// CacheNotFound records for NotFoundTTL that the resource cached under key does not
+// exist if err reports that the player does not exist, so the next lookups of key
+// return errors.ErrPlayerNotFound without a request (see CachedNotFound). Other errors,
+// including errors.ErrProfileNotCachedYet, which wraps errors.ErrPlayerNotFound but
+// means the player exists, are not cached.
+//
+// Game-specific clients call it with the error of a fetch before handling it.
+func (c *Client) CacheNotFound(key string, err error) {
+	if c.Cache == nil || c.NotFoundTTL <= 0 {
+		return
+	}
+	if !errors.Is(err, errors.ErrPlayerNotFound) || errors.Is(err, errors.ErrProfileNotCachedYet) {
+		return
+	}
+	c.Cache.Set(NotFoundKey(key), NotFound{}, c.NotFoundTTL)
+}
+
+// CachedNotFound returns errors.ErrPlayerNotFound if CacheNotFound recorded that the
+// resource cached under key does not exist, or nil otherwise. Game-specific clients
+// call it when the cache holds no response for key.
+func (c *Client) CachedNotFound(key string) error {
+	if c.Cache == nil || c.NotFoundTTL <= 0 {
+		return nil
+	}
+	if _, ok := c.Cache.Get(NotFoundKey(key)); ok {
+		return errors.ErrPlayerNotFound
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/kirinyoku/enkanetwork-go/cache"
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// TestCacheNotFound checks that not found results are cached under their own key, without
+// replacing the cached response, and that other errors are not cached.
+func TestCacheNotFound(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"not found", errors.ErrPlayerNotFound, errors.ErrPlayerNotFound},
+		{"not cached yet", errors.ErrProfileNotCachedYet, nil},
+		{"server error", errors.ErrServerError, nil},
+	}
+
+	for _, tt := range tests {
+		lru := cache.NewLRU(10)
+		c := New(WithCache(lru), WithNotFoundTTL(time.Minute))
+		c.Cache.Set("genshin_618285856", "stale profile", time.Minute)
+
+		c.CacheNotFound("genshin_618285856", tt.err)
+		if err := c.CachedNotFound("genshin_618285856"); err != tt.want {
+			t.Errorf("%s: CachedNotFound() = %v, want %v", tt.name, err, tt.want)
+		}
+		if cached, _ := c.Cache.Get("genshin_618285856"); cached != "stale profile" {
+			t.Errorf("%s: cached response = %v, want it unchanged", tt.name, cached)
+		}
+		lru.Close()
+	}
+
+	if err := New(WithCache(cache.NewLRU(10))).CachedNotFound("genshin_618285856"); err != nil {
+		t.Errorf("CachedNotFound() without NotFoundTTL = %v, want nil", err)
+	}
+}
+
+// TestNotFoundSerializable checks that the cached value survives a JSON round trip, as
+// done by caches serializing their values.
+func TestNotFoundSerializable(t *testing.T) {
+	data, err := json.Marshal(NotFound{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var value NotFound
+	if err := json.Unmarshal(data, &value); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if key := NotFoundKey(CacheKey("genshin", "618285856")); key != "notfound_genshin_618285856" {
+		t.Errorf("NotFoundKey() = %q", key)
+	}
+}
@@ -0,0 +1,419 @@
+package core
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RetryConfig controls how requests that fail with a transient error (429, 500, 503, or a
+// temporary network error such as a timeout) are retried.
+//
+// Fields:
+//   - MaxAttempts: The maximum number of attempts made for a single request, including
+//     the first one. A value of 1 disables retries (see WithNoRetry); zero or less
+//     selects the default.
+//   - DefaultDelay: The delay between attempts when the API does not provide a
+//     Retry-After header.
+//   - MaxRetryAfter: The longest delay requested by a Retry-After header that is
+//     waited for. If the API asks to wait longer, the request fails immediately with
+//     errors.ErrRateLimited (errors.ErrServiceUnavailable for a 503 response) instead of
+//     blocking until then.
+//
+// 424 Failed Dependency responses, which the API sends during maintenance, are only
+// retried with WithRetryOnMaintenance.
+type RetryConfig struct {
+	MaxAttempts   int           // Maximum number of attempts for a single request
+	DefaultDelay  time.Duration // Delay between attempts if Retry-After is not present
+	MaxRetryAfter time.Duration // Longest Retry-After delay that is waited for
+}
+
+// DefaultRetryConfig is the retry configuration used when none is provided: up to 3
+// attempts with a 5-second delay between them, waiting at most 60 seconds when the API
+// sends a Retry-After header.
+var DefaultRetryConfig = RetryConfig{
+	MaxAttempts:   3,
+	DefaultDelay:  5 * time.Second,
+	MaxRetryAfter: 60 * time.Second,
+}
+
+// DefaultMaxResponseSize is the maximum size of a response body used when none is
+// provided: 16 MB, well above the size of the largest profiles returned by the API.
+const DefaultMaxResponseSize = 16 << 20
+
+// DefaultFallbackTimeout is the timeout applied to a request attempt when neither the
+// HTTP client, the caller's context nor WithRequestTimeout bound it (see
+// WithFallbackTimeout).
+const DefaultFallbackTimeout = 30 * time.Second
+
+// Option configures a Client created with New. Options are applied in the order
+// they are passed, so a later option overrides an earlier one.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for making requests. If nil or not
+// provided, a default HTTP client with a 10-second timeout is used.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithTransport sets the http.RoundTripper used to send requests, such as an
+// *http.Transport configured with a corporate proxy or client certificates for mutual
+// TLS, while keeping the default HTTP client and its 10-second timeout. If
+// WithHTTPClient is also used, a copy of that client is made with the transport
+// installed; the client passed to WithHTTPClient is not modified. If nil or not
+// provided, http.DefaultTransport is used.
+//
+// The transport composes with WithRequestTimeout, which is applied per request and does
+// not replace the HTTP client.
+//
+// Example:
+//
+//	transport := http.DefaultTransport.(*http.Transport).Clone()
+//	transport.Proxy = http.ProxyURL(proxyURL)
+//	client := genshin.New(genshin.WithTransport(transport))
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.transport = rt
+	}
+}
+
+// WithHTTPDoer sets the HTTPDoer used to send requests instead of the HTTP client. It is
+// mainly meant for tests, which can provide an HTTPDoerFunc returning canned responses
+// and errors, such as a net.Error timeout, to exercise the retry logic without a server.
+// The doer takes precedence over WithHTTPClient and WithTransport; WithRequestTimeout
+// still applies through the request context. If nil or not provided, the HTTP client is
+// used.
+//
+// Example:
+//
+//	doer := genshin.HTTPDoerFunc(func(req *http.Request) (*http.Response, error) {
+//	    return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}, nil
+//	})
+//	client := genshin.New(genshin.WithHTTPDoer(doer))
+func WithHTTPDoer(doer HTTPDoer) Option {
+	return func(c *Client) {
+		c.Doer = doer
+	}
+}
+
+// WithCache sets the cache used to store API responses. If nil or not provided,
+// caching is disabled.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		c.Cache = cache
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request. If empty or not
+// provided, DefaultUserAgent is used.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithUserAgentSuffix appends suffix, separated by a space, to the User-Agent set with
+// WithUserAgent, or to DefaultUserAgent if none is set. It lets an application built on
+// the library identify itself while keeping the library's User-Agent, e.g.
+// "enkanetwork-go/0.5.5 my-app/2.3". Each call appends to the previous suffix. A suffix
+// counts as a custom User-Agent for WithRequireUserAgent.
+//
+// Example:
+//
+//	client := genshin.New(genshin.WithUserAgentSuffix("my-app/2.3"))
+func WithUserAgentSuffix(suffix string) Option {
+	return func(c *Client) {
+		c.userAgentSuffix = strings.TrimSpace(c.userAgentSuffix + " " + suffix)
+	}
+}
+
+// WithRetryConfig sets how requests failing with a transient error are retried.
+// If not provided, DefaultRetryConfig is used.
+func WithRetryConfig(cfg RetryConfig) Option {
+	return func(c *Client) {
+		c.Retry = cfg
+	}
+}
+
+// WithNoRetry disables retries: every request is attempted once, and an error response
+// is returned immediately instead of waiting to retry it. A 429 Too Many Requests
+// response results in errors.ErrRateLimited, a 500 in errors.ErrServerError and a 503
+// in errors.ErrServiceUnavailable. It is a shorthand for WithRetryConfig with a
+// MaxAttempts of 1, and is useful for latency-sensitive callers that prefer an immediate
+// error over waiting for the delay between retries.
+func WithNoRetry() Option {
+	return func(c *Client) {
+		c.Retry = RetryConfig{MaxAttempts: 1}
+	}
+}
+
+// WithRetryBudget sets a budget that is consulted before every retry. When it is
+// exhausted, requests fail immediately with the error of the last response, such as
+// errors.ErrRateLimited or errors.ErrServerMaintenance, instead of retrying. The budget
+// can be shared by several clients to bound the total volume of retries across a batch,
+// such as GetProfiles over many UIDs during an outage. If nil or not provided, every request
+// retries up to RetryConfig.MaxAttempts independently.
+//
+// Example:
+//
+//	// Allow at most 20 retries per minute across both clients
+//	budget := genshin.NewRetryBudget(20, time.Minute)
+//	gi := genshin.New(genshin.WithRetryBudget(budget))
+//	sr := hsr.New(hsr.WithRetryBudget(budget))
+func WithRetryBudget(budget *RetryBudget) Option {
+	return func(c *Client) {
+		c.RetryBudget = budget
+	}
+}
+
+// WithRetryOnMaintenance makes requests failing with 424 Failed Dependency, which the
+// API returns during maintenance, be retried with the same delay as other transient
+// errors. Maintenance windows are often brief, so this suits background workers that
+// prefer waiting over failing. Once the attempts are exhausted, errors.ErrServerMaintenance
+// is returned.
+//
+// The attempts and the delay between them are those of the retry configuration, so with
+// WithNoRetry a 424 response still fails immediately. The option is independent of
+// WithRetryConfig and WithNoRetry, which do not reset it, and may be passed before or
+// after them.
+func WithRetryOnMaintenance() Option {
+	return func(c *Client) {
+		c.RetryOnMaintenance = true
+	}
+}
+
+// WithRequireUserAgent makes a custom User-Agent mandatory. The EnkaNetwork API asks
+// clients to identify their application, and generic User-Agents may be rate limited
+// or blocked. If no User-Agent is provided with WithUserAgent or WithUserAgentSuffix,
+// the client reports errors.ErrUserAgentRequired from Err and from every request
+// instead of falling back to DefaultUserAgent.
+func WithRequireUserAgent() Option {
+	return func(c *Client) {
+		c.requireUserAgent = true
+	}
+}
+
+// WithRateLimiter sets a rate limiter that is waited on before every request attempt,
+// including retries. The limiter applies to all requests made by the client, so a
+// client shared across goroutines is limited as a whole. If nil or not provided,
+// requests are not limited.
+//
+// Example:
+//
+//	// Allow at most 10 requests per second
+//	client := genshin.New(genshin.WithRateLimiter(rate.NewLimiter(10, 1)))
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(c *Client) {
+		c.RateLimiter = limiter
+	}
+}
+
+// WithMaxConcurrency limits the number of requests of the client in flight at once to n,
+// wherever they are made from, so a burst of goroutines sharing the client cannot open
+// dozens of connections at once. Each request attempt waits for a free slot, respecting
+// the cancellation of its context, and holds it until its response has been read. The
+// limit is per client; share the client to apply it across an application. If zero or
+// not provided, the number of requests in flight is not limited.
+//
+// Example:
+//
+//	// At most 5 requests to EnkaNetwork at any time
+//	client := genshin.New(genshin.WithMaxConcurrency(5))
+func WithMaxConcurrency(n int) Option {
+	return func(c *Client) {
+		c.MaxConcurrency = n
+	}
+}
+
+// WithRequestTimeout sets a timeout applied to each request attempt, without the need
+// to provide a custom HTTP client. The timeout is applied through a context derived
+// from the one passed to the request method, so a shorter deadline set by the caller
+// still takes precedence. Delays between retries are not counted. If zero or not
+// provided, only the HTTP client's timeout and the caller's context apply.
+func WithRequestTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.RequestTimeout = d
+	}
+}
+
+// WithFallbackTimeout sets the timeout applied to a request attempt when nothing else
+// bounds it: the HTTP client has no Timeout (or a Doer is used), the caller's context has
+// no deadline and WithRequestTimeout is not used. It keeps a hung connection from
+// blocking a request forever, e.g. with a custom HTTP client and context.Background().
+// If not provided, DefaultFallbackTimeout is used; zero or a negative duration disables
+// it.
+func WithFallbackTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.FallbackTimeout = d
+	}
+}
+
+// WithMaxResponseSize sets the maximum size of a response body in bytes. Reading stops
+// once the limit is exceeded and the request fails with errors.ErrResponseTooLarge, so a
+// misbehaving endpoint cannot exhaust the memory of the application. If zero or less or
+// not provided, DefaultMaxResponseSize is used.
+func WithMaxResponseSize(n int64) Option {
+	return func(c *Client) {
+		c.MaxResponseSize = n
+	}
+}
+
+// WithClock sets the clock used to wait between retries and to interpret Retry-After
+// dates. It is meant for tests, which can provide a fake clock to check retry delays
+// without waiting for them. If nil or not provided, DefaultClock is used.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		c.Clock = clock
+	}
+}
+
+// WithAPIVersion sets a version inserted between BaseURL and the path of every endpoint,
+// e.g. "v2" to send requests to "https://enka.network/api/v2/...". It allows switching
+// to a new version of the API, should one be introduced, without changing the library.
+// If empty or not provided, the current unversioned paths are used.
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		c.APIVersion = version
+	}
+}
+
+// WithLanguage sets the language of the responses of the endpoints that support
+// localization, such as the builds of a hoyo account. The language is sent as the lang
+// query parameter and is part of the cache key, so clients with different languages can
+// share a cache. Profile endpoints are not localized by the API and ignore it.
+//
+// The language must be one of SupportedLanguages (e.g., "en", "ja" or "zh-CN");
+// otherwise Err and every request return errors.ErrInvalidLanguage. If empty or not
+// provided, no language is sent and the API default is used.
+func WithLanguage(lang string) Option {
+	return func(c *Client) {
+		c.Language = lang
+	}
+}
+
+// WithMinCacheTTL sets the shortest time a response is cached. The expiration derived
+// from the ttl field of a profile, or DefaultCacheTTL for responses without one, is raised
+// to d if it is shorter, so a short ttl reported by the API does not cause frequent
+// requests. Responses without a positive ttl are still not cached. If zero or not
+// provided, expirations are not raised.
+func WithMinCacheTTL(d time.Duration) Option {
+	return func(c *Client) {
+		c.MinCacheTTL = d
+	}
+}
+
+// WithMaxCacheTTL sets the longest time a response is cached. The expiration derived from
+// the ttl field of a profile, or DefaultCacheTTL for responses without one, is lowered to
+// d if it is longer. If zero or not provided, expirations are not lowered. It takes
+// precedence over WithMinCacheTTL if the two conflict.
+func WithMaxCacheTTL(d time.Duration) Option {
+	return func(c *Client) {
+		c.MaxCacheTTL = d
+	}
+}
+
+// WithNotFoundTTL caches the outcome of requests for players that do not exist for ttl,
+// so repeated lookups of the same UID return errors.ErrPlayerNotFound from the cache
+// instead of calling the API again, e.g. when polling a watchlist. Only a plain not found
+// result is cached: errors.ErrProfileNotCachedYet, rate limits, server errors and other
+// transient failures are not. It requires a cache (see WithCache). If zero or not
+// provided, not found results are not cached.
+//
+// Not found results are stored under their own keys, prefixed with "notfound_", so they
+// never replace a cached profile, such as the expired one returned by
+// WithServeStaleOnError. The stored value is an empty struct that caches serializing
+// their values, such as cache.FileCache or a Redis adapter encoding JSON, can store.
+func WithNotFoundTTL(ttl time.Duration) Option {
+	return func(c *Client) {
+		c.NotFoundTTL = ttl
+	}
+}
+
+// WithCaseInsensitiveUsernames makes lookups of Enka usernames that differ only in case,
+// such as "Algoinde" and "algoinde", share their cache entries, which are stored under
+// the lowercased username. Requests are still sent with the username as given, so the
+// API decides whether the lookup succeeds. Use it only if the usernames your users type
+// are resolved by the API regardless of case; otherwise the cached response for one
+// spelling would be returned for the others. If not provided, usernames are cached as
+// given.
+func WithCaseInsensitiveUsernames() Option {
+	return func(c *Client) {
+		c.CaseInsensitiveUsernames = true
+	}
+}
+
+// WithCacheErrorHandler sets a function called when a value read from the cache cannot
+// be used, with the cache key and an error describing the problem. Such values are
+// ignored and the resource is fetched again, so without a handler the problem only shows
+// as additional requests. The error wraps errors.ErrCacheTypeMismatch when the value has
+// an unexpected type, e.g. after a library upgrade changed the cached types, or is the
+// error stored by the cache in place of a value it failed to decode, such as a
+// *cache.DecodeError. The handler is called synchronously and must be safe for
+// concurrent use.
+//
+// Example:
+//
+//	client := genshin.New(genshin.WithCacheErrorHandler(func(key string, err error) {
+//	    log.Printf("unusable cache entry %s: %v", key, err)
+//	}))
+func WithCacheErrorHandler(fn func(key string, err error)) Option {
+	return func(c *Client) {
+		c.CacheErrorHandler = fn
+	}
+}
+
+// WithServeStaleOnError makes requests that fail with a transient error (see IsTransient)
+// return the last cached value of the resource, even if it has expired, instead of
+// nothing. The value is returned along with an error wrapping errors.ErrStaleData and the
+// failure, so callers that want stale data check for it explicitly:
+//
+//	profile, err := client.GetProfile(ctx, uid)
+//	if errors.Is(err, genshin.ErrStaleData) {
+//	    log.Println("showing cached data:", err)
+//	} else if err != nil {
+//	    return err
+//	}
+//
+// It requires a cache implementing StaleCache, which keeps entries after they expire,
+// such as a cache.LRU with SetStaleRetention. With other caches, or if no value was
+// cached, the error is returned as usual.
+func WithServeStaleOnError() Option {
+	return func(c *Client) {
+		c.ServeStaleOnError = true
+	}
+}
+
+// WithConditionalRequests enables conditional requests. The ETag of every successful
+// response is stored along with its body, and later requests for the same URL are sent
+// with an If-None-Match header. If the API answers with 304 Not Modified, the stored
+// body is returned as if it was downloaded again, so the response is cached anew with
+// a fresh expiration.
+//
+// Responses without an ETag are not stored, so if the API does not send ETags this
+// option has no effect besides the lookup. The bodies of up to DefaultMaxETags URLs are
+// kept in memory; the least recently used ones are evicted beyond that.
+func WithConditionalRequests() Option {
+	return func(c *Client) {
+		c.ETags = NewETagStore(DefaultMaxETags)
+	}
+}
+
+// WithHeaders sets additional headers sent with every request, such as an Authorization
+// or X-Api-Key header required by a proxy in front of the API. The headers are copied,
+// so later changes to the map have no effect. Calling WithHeaders again adds to the
+// headers set before.
+//
+// Headers set for a single request with WithRequestHeaders take precedence over these.
+// The User-Agent header cannot be set this way; it is always taken from WithUserAgent.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header, len(headers))
+		}
+		for key, value := range headers {
+			c.Headers.Set(key, value)
+		}
+	}
+}
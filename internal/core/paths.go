@@ -0,0 +1,48 @@
+package core
+
+// Endpoint paths of the EnkaNetwork API, relative to BaseURL. The clients build the URL
+// of every request from these functions, so a change to the API's URL layout only needs
+// to be made here. The arguments are expected to be validated by the caller (see
+// IsValidUID, IsValidUsername and IsValidHoyoHash).
+
+// GenshinUIDPath returns the path of the Genshin Impact profile of uid.
+func GenshinUIDPath(uid string) string {
+	return "/uid/" + uid
+}
+
+// GenshinPlayerInfoPath returns the path of the Genshin Impact player info of uid, which
+// excludes the character details.
+func GenshinPlayerInfoPath(uid string) string {
+	return GenshinUIDPath(uid) + "?info"
+}
+
+// HSRUIDPath returns the path of the Honkai: Star Rail profile of uid.
+func HSRUIDPath(uid string) string {
+	return "/hsr/uid/" + uid
+}
+
+// ZZZUIDPath returns the path of the Zenless Zone Zero profile of uid.
+func ZZZUIDPath(uid string) string {
+	return "/zzz/uid/" + uid
+}
+
+// EnkaProfilePath returns the path of the Enka user profile of username.
+func EnkaProfilePath(username string) string {
+	return "/profile/" + username
+}
+
+// EnkaHoyosPath returns the path of the hoyo accounts linked to the Enka user username.
+func EnkaHoyosPath(username string) string {
+	return EnkaProfilePath(username) + "/hoyos"
+}
+
+// EnkaHoyoPath returns the path of the hoyo account hoyoHash of the Enka user username.
+func EnkaHoyoPath(username, hoyoHash string) string {
+	return EnkaHoyosPath(username) + "/" + hoyoHash
+}
+
+// EnkaBuildsPath returns the path of the builds of the hoyo account hoyoHash of the Enka
+// user username.
+func EnkaBuildsPath(username, hoyoHash string) string {
+	return EnkaHoyoPath(username, hoyoHash) + "/builds"
+}
@@ -0,0 +1,25 @@
+package core
+
+import "testing"
+
+// TestPaths checks the endpoint paths against the URL layout of the API.
+func TestPaths(t *testing.T) {
+	tests := []struct {
+		got, want string
+	}{
+		{GenshinUIDPath("618285856"), "/uid/618285856"},
+		{GenshinPlayerInfoPath("618285856"), "/uid/618285856?info"},
+		{HSRUIDPath("800000000"), "/hsr/uid/800000000"},
+		{ZZZUIDPath("1301806568"), "/zzz/uid/1301806568"},
+		{EnkaProfilePath("Algoinde"), "/profile/Algoinde"},
+		{EnkaHoyosPath("Algoinde"), "/profile/Algoinde/hoyos"},
+		{EnkaHoyoPath("Algoinde", "4Wjv2e"), "/profile/Algoinde/hoyos/4Wjv2e"},
+		{EnkaBuildsPath("Algoinde", "4Wjv2e"), "/profile/Algoinde/hoyos/4Wjv2e/builds"},
+	}
+
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("path = %q, want %q", tt.got, tt.want)
+		}
+	}
+}
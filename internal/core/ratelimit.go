@@ -0,0 +1,27 @@
+package core
+
+import "context"
+
+// RateLimiter defines an interface for limiting the rate of requests sent to the API.
+// Retries handle 429 (Too Many Requests) responses after the fact; a rate limiter lets
+// the client stay under the API's limits proactively. *rate.Limiter from
+// golang.org/x/time/rate implements this interface.
+type RateLimiter interface {
+	// Wait blocks until a request is allowed to be sent or the context is done.
+	// It returns an error if the request cannot be sent, e.g. because the context
+	// was canceled.
+	Wait(ctx context.Context) error
+}
+
+// AcquireSlot waits until fewer than MaxConcurrency requests of the client are in flight,
+// or until ctx is done, and returns a function releasing the slot once the request has
+// completed. If the client has no MaxConcurrency, it returns immediately.
+func (c *Client) AcquireSlot(ctx context.Context) (release func(), err error) {
+	if c.concurrency == nil {
+		return func() {}, nil
+	}
+	if err := c.concurrency.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { c.concurrency.Release(1) }, nil
+}
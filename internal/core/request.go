@@ -0,0 +1,101 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// NewRequest creates a request to url with the headers configured on the client: the
+// headers set with WithHeaders and WithRequestHeaders, the User-Agent and the
+// X-Request-ID set with WithRequestID. It is used by the fetcher and Ping, so all
+// requests sent by the library carry the same headers.
+func NewRequest(ctx context.Context, c *Client, method, url string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.Headers != nil {
+		req.Header = c.Headers.Clone()
+	}
+	for key, values := range RequestHeadersFromContext(ctx) {
+		req.Header[key] = slices.Clone(values)
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	if id, ok := RequestIDFromContext(ctx); ok {
+		req.Header.Set("X-Request-ID", id)
+	}
+
+	return req, nil
+}
+
+// Ping checks that the EnkaNetwork API is reachable and available by sending a HEAD
+// request to its root URL. It returns nil if the API responds with a 2xx or 3xx status,
+// and does not fetch any player data, so it is a cheap check before starting a batch of requests. The request
+// is not retried.
+//
+// Possible errors include:
+//   - The error returned by Err if the client configuration is invalid.
+//   - errors.ErrServerMaintenance: If the API reports maintenance (424).
+//   - errors.ErrRateLimited: If the rate limit is exceeded (429).
+//   - errors.ErrServiceUnavailable: If the API is unavailable (503).
+//   - errors.ErrServerError: For other server errors (5xx).
+//   - An *errors.APIError wrapping errors.ErrUnexpectedStatus for other statuses, such
+//     as 403 if the User-Agent is blocked. Its StatusCode holds the status.
+//   - A network error if the API cannot be reached.
+//
+// Example:
+//
+//	if err := client.Ping(ctx); err != nil {
+//	    fmt.Println("EnkaNetwork is not available:", err)
+//	    return
+//	}
+func (c *Client) Ping(ctx context.Context) error {
+	if c.err != nil {
+		return c.err
+	}
+
+	if c.RateLimiter != nil {
+		if err := c.RateLimiter.Wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	release, err := c.AcquireSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
+	ctx, cancel := c.AttemptContext(ctx)
+	defer cancel()
+
+	req, err := NewRequest(ctx, c, http.MethodHead, c.URL("/"))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Send(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 400:
+		return nil
+	case resp.StatusCode == http.StatusFailedDependency:
+		return errors.ErrServerMaintenance
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return errors.ErrRateLimited
+	case resp.StatusCode == http.StatusServiceUnavailable:
+		return errors.ErrServiceUnavailable
+	case resp.StatusCode >= 500:
+		return errors.ErrServerError
+	default:
+		return &errors.APIError{StatusCode: resp.StatusCode, Err: errors.ErrUnexpectedStatus}
+	}
+}
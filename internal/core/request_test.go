@@ -0,0 +1,75 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// redirectTransport sends all requests to the given server instead of their URL.
+type redirectTransport struct {
+	target *url.URL
+}
+
+func (t redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// TestPing checks that Ping maps the status of the API to an error.
+func TestPing(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusOK, nil},
+		{http.StatusNoContent, nil},
+		{http.StatusMovedPermanently, nil},
+		{http.StatusNotFound, errors.ErrUnexpectedStatus},
+		{http.StatusForbidden, errors.ErrUnexpectedStatus},
+		{http.StatusFailedDependency, errors.ErrServerMaintenance},
+		{http.StatusTooManyRequests, errors.ErrRateLimited},
+		{http.StatusServiceUnavailable, errors.ErrServiceUnavailable},
+		{http.StatusBadGateway, errors.ErrServerError},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodHead {
+				t.Errorf("expected a HEAD request, got %s", r.Method)
+			}
+			w.WriteHeader(tt.status)
+		}))
+
+		target, _ := url.Parse(server.URL)
+		c := New(WithHTTPClient(&http.Client{Transport: redirectTransport{target}}))
+		err := c.Ping(context.Background())
+		if tt.want == errors.ErrUnexpectedStatus {
+			var apiErr *errors.APIError
+			if !errors.As(err, &apiErr) || apiErr.Err != errors.ErrUnexpectedStatus || apiErr.StatusCode != tt.status {
+				t.Errorf("status %d: expected an APIError with the status, got %v", tt.status, err)
+			}
+		} else if err != tt.want {
+			t.Errorf("status %d: expected %v, got %v", tt.status, tt.want, err)
+		}
+
+		server.Close()
+	}
+}
+
+// TestNewRequestDefaultUserAgent checks that requests identify the library and its version by default.
+func TestNewRequestDefaultUserAgent(t *testing.T) {
+	req, err := NewRequest(context.Background(), New(), http.MethodGet, BaseURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := req.Header.Get("User-Agent"), "enkanetwork-go/"+Version; got != want {
+		t.Errorf("User-Agent = %q, want %q", got, want)
+	}
+}
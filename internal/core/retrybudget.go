@@ -0,0 +1,59 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// RetryBudget bounds the total number of retries made by the requests that share it,
+// using a token bucket: every retry takes a token, and tokens are refilled at a steady
+// rate up to the capacity of the bucket. Once the budget is exhausted, requests fail
+// instead of retrying, so a brief outage during a large batch does not turn into a
+// flood of retries.
+//
+// A RetryBudget is safe for concurrent use and can be shared by several clients with
+// WithRetryBudget.
+type RetryBudget struct {
+	mu       sync.Mutex
+	clock    Clock
+	tokens   float64   // Tokens currently available
+	capacity float64   // Maximum number of tokens
+	rate     float64   // Tokens refilled per second
+	last     time.Time // Time of the last refill
+}
+
+// NewRetryBudget creates a RetryBudget that allows up to retries retries at once and
+// refills them over the duration per, e.g. NewRetryBudget(50, time.Minute) allows bursts
+// of 50 retries and 50 more every minute. If per is zero or less, the budget is never
+// refilled.
+func NewRetryBudget(retries int, per time.Duration) *RetryBudget {
+	b := &RetryBudget{
+		clock:    DefaultClock,
+		tokens:   float64(retries),
+		capacity: float64(retries),
+	}
+	if per > 0 {
+		b.rate = float64(retries) / per.Seconds()
+	}
+	b.last = b.clock.Now()
+	return b
+}
+
+// Allow takes a token from the budget and reports whether a retry is allowed. It
+// returns false without waiting if the budget is exhausted.
+func (b *RetryBudget) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.clock.Now()
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(b.capacity, b.tokens+elapsed.Seconds()*b.rate)
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
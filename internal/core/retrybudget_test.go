@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+// manualClock is a Clock whose time only changes when advanced by the test.
+type manualClock struct {
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	return c.now
+}
+
+func (c *manualClock) After(d time.Duration) <-chan time.Time {
+	c.now = c.now.Add(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
+
+func TestRetryBudget(t *testing.T) {
+	clock := &manualClock{now: time.Unix(0, 0)}
+	b := NewRetryBudget(2, time.Minute)
+	b.clock, b.last = clock, clock.now
+
+	tests := []struct {
+		name    string
+		advance time.Duration
+		want    bool
+	}{
+		{"first token", 0, true},
+		{"second token", 0, true},
+		{"exhausted", 0, false},
+		{"partially refilled", 10 * time.Second, false},
+		{"refilled one token", 20 * time.Second, true},
+		{"exhausted again", 0, false},
+		{"refill capped at capacity", time.Hour, true},
+		{"second token after refill", 0, true},
+		{"exhausted after refill", 0, false},
+	}
+
+	for _, tt := range tests {
+		clock.now = clock.now.Add(tt.advance)
+		if got := b.Allow(); got != tt.want {
+			t.Errorf("%s: Allow() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/core/errors"
+)
+
+// StaleCache is an optional interface of a Cache that keeps entries for some time after
+// they expire. It is used by WithServeStaleOnError to return the last known value of a
+// resource when refreshing it fails. cache.LRU implements it once SetStaleRetention is
+// called.
+type StaleCache interface {
+	// GetStale retrieves a value from the cache by key, even if it has expired.
+	// Returns the cached value and true if found, or nil and false if not found.
+	GetStale(key string) (any, bool)
+}
+
+// IsTransient reports whether err is a transient failure of the API or the network,
+// after which a later request may succeed: errors.ErrRateLimited,
+// errors.ErrServerMaintenance, errors.ErrServerError, errors.ErrServiceUnavailable or a
+// network error, such as a timeout or a refused connection.
+func IsTransient(err error) bool {
+	if errors.Is(err, errors.ErrRateLimited) ||
+		errors.Is(err, errors.ErrServerMaintenance) ||
+		errors.Is(err, errors.ErrServerError) ||
+		errors.Is(err, errors.ErrServiceUnavailable) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// StaleOnError handles err, the error of a request for the resource cached under key.
+// If the client serves stale data on error, err is transient and the cache still holds
+// a value of type T for key, even an expired one, that value is returned along with an
+// error wrapping both errors.ErrStaleData and err. Otherwise the zero value of T and err
+// are returned unchanged.
+//
+// Game-specific clients call it in place of returning the error of a fetch, e.g.
+// return core.StaleOnError[*Profile](c.Client, key, err).
+func StaleOnError[T any](c *Client, key string, err error) (T, error) {
+	var zero T
+	if !c.ServeStaleOnError || !IsTransient(err) {
+		return zero, err
+	}
+
+	cache, ok := c.Cache.(StaleCache)
+	if !ok {
+		return zero, err
+	}
+
+	cached, ok := cache.GetStale(key)
+	if !ok {
+		return zero, err
+	}
+	value, ok := cached.(T)
+	if !ok {
+		return zero, err
+	}
+
+	return value, fmt.Errorf("%w: %w", errors.ErrStaleData, err)
+}
@@ -1,6 +1,17 @@
 package core
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // isValidUID checks if the provided UID is a valid 9-digit number.
 // Genshin and HSR UID can only be 9 digits (e.g., "618285856").
@@ -23,6 +34,74 @@ func IsValidUID(uid string) bool {
 	return true
 }
 
+// maxUsernameLength is the maximum length of an EnkaNetwork username.
+const maxUsernameLength = 150
+
+// IsValidUsername checks if the provided string is a valid EnkaNetwork username.
+// A valid username is 1 to 150 characters long and consists only of letters, digits
+// and the characters '@', '.', '+', '-' and '_'. The names "." and "..", which would
+// change the meaning of the request path, are rejected.
+//
+// Parameters:
+//   - username: The username to validate.
+//
+// Returns:
+//   - true if the username matches the rules above, false otherwise.
+func IsValidUsername(username string) bool {
+	if username == "" || username == "." || username == ".." {
+		return false
+	}
+	if utf8.RuneCountInString(username) > maxUsernameLength {
+		return false
+	}
+	for _, r := range username {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+		case r == '@', r == '.', r == '+', r == '-', r == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// maxHoyoHashLength is the maximum accepted length of a hoyo hash.
+const maxHoyoHashLength = 16
+
+// IsValidHoyoHash checks if the provided string is a valid hoyo hash, the identifier
+// of a game account linked to an EnkaNetwork profile (e.g., "4Wjv2e").
+// A valid hoyo hash is 1 to 16 characters long and consists only of ASCII letters
+// and digits.
+//
+// Parameters:
+//   - hash: The hoyo hash to validate.
+//
+// Returns:
+//   - true if the hash matches the rules above, false otherwise.
+func IsValidHoyoHash(hash string) bool {
+	if hash == "" || len(hash) > maxHoyoHashLength {
+		return false
+	}
+	for _, r := range hash {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// SupportedLanguages lists the language codes supported by EnkaNetwork, as accepted by
+// WithLanguage.
+var SupportedLanguages = []string{
+	"en", "ru", "vi", "th", "pt", "ko", "ja", "id", "fr", "es", "de", "zh-TW", "zh-CN", "it", "tr",
+}
+
+// IsValidLanguage checks if the provided string is one of SupportedLanguages (e.g., "en"
+// or "zh-CN"). The comparison is case-sensitive.
+func IsValidLanguage(lang string) bool {
+	return slices.Contains(SupportedLanguages, lang)
+}
+
 // removeTTLField removes the TTL field from the JSON response.
 // This is used for tests to ensure the response is consistent.
 func RemoveTTLField(jsonBytes []byte) []byte {
@@ -36,3 +115,137 @@ func RemoveTTLField(jsonBytes []byte) []byte {
 	newJSON, _ := json.Marshal(profile)
 	return newJSON
 }
+
+// CompareNumeric compares two strings holding decimal numbers, such as avatar IDs or
+// the order of builds, which the API returns as strings. If both strings are valid
+// integers they are compared numerically, otherwise they are compared lexically.
+//
+// Returns:
+//   - -1 if a sorts before b, 0 if they are equal, and +1 if a sorts after b.
+func CompareNumeric(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	if errA == nil && errB == nil {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(a, b)
+}
+
+// DiffJSONKeys returns the keys present in the API response but missing from the JSON
+// produced by marshaling the client's structs. It is used by the integration tests to
+// detect fields the API returns that are not modeled by the library, without failing
+// on differences in key order or formatting.
+//
+// Nested keys are reported as dot-separated paths. Keys of objects inside arrays are
+// reported with a "[]" suffix on the array name (e.g., "avatarInfoList[].propMap"),
+// and the keys of all elements of an array are merged before comparison.
+//
+// Parameters:
+//   - apiBytes: The JSON response returned by the API.
+//   - clientBytes: The JSON produced from the client's decoded response.
+//
+// Returns:
+//   - A sorted list of key paths present in apiBytes but not in clientBytes. If either
+//     input is not valid JSON, a single entry describing the error is returned.
+func DiffJSONKeys(apiBytes, clientBytes []byte) []string {
+	var api, client any
+	if err := json.Unmarshal(apiBytes, &api); err != nil {
+		return []string{fmt.Sprintf("invalid API JSON: %v", err)}
+	}
+	if err := json.Unmarshal(clientBytes, &client); err != nil {
+		return []string{fmt.Sprintf("invalid client JSON: %v", err)}
+	}
+
+	apiKeys := make(map[string]bool)
+	collectJSONKeys(api, "", apiKeys)
+	clientKeys := make(map[string]bool)
+	collectJSONKeys(client, "", clientKeys)
+
+	var missing []string
+	for key := range apiKeys {
+		if !clientKeys[key] {
+			missing = append(missing, key)
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
+
+// NormalizeJSON returns data in a canonical form, so that two JSON documents holding the
+// same values compare equal byte for byte regardless of cosmetic differences. Object
+// keys are sorted, insignificant whitespace is removed and numbers are written in their
+// shortest form (e.g., 1.0 and 1e0 become 1). It is used by the tests to compare API
+// responses with the JSON produced by the client's structs.
+//
+// Integers that fit in an int64 are kept exact. If data is not valid
+// JSON, it is returned unchanged.
+func NormalizeJSON(data []byte) []byte {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return data
+	}
+
+	normalized, err := json.Marshal(normalizeNumbers(v))
+	if err != nil {
+		return data
+	}
+	return normalized
+}
+
+// normalizeNumbers returns v with every json.Number replaced by its shortest form.
+// Maps are marshaled with sorted keys by encoding/json, so only numbers need rewriting.
+func normalizeNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			v[key] = normalizeNumbers(value)
+		}
+	case []any:
+		for i, value := range v {
+			v[i] = normalizeNumbers(value)
+		}
+	case json.Number:
+		if n, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return json.Number(strconv.FormatInt(n, 10))
+		}
+		if f, err := v.Float64(); err == nil {
+			if f == math.Trunc(f) && math.Abs(f) < 1<<53 {
+				return json.Number(strconv.FormatInt(int64(f), 10))
+			}
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+	}
+	return v
+}
+
+// collectJSONKeys adds the key paths of the decoded JSON value v, prefixed with path,
+// to keys.
+func collectJSONKeys(v any, path string, keys map[string]bool) {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			p := key
+			if path != "" {
+				p = path + "." + key
+			}
+			keys[p] = true
+			collectJSONKeys(value, p, keys)
+		}
+	case []any:
+		for _, value := range v {
+			collectJSONKeys(value, path+"[]", keys)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestDiffJSONKeys checks that keys missing from the client JSON are reported, including nested ones.
+func TestDiffJSONKeys(t *testing.T) {
+	api := []byte(`{"uid":"1","playerInfo":{"nickname":"a","newField":1},"list":[{"a":1},{"b":2}]}`)
+	client := []byte(`{"list":[{"a":1}],"playerInfo":{"nickname":"a"},"uid":"1"}`)
+
+	want := []string{"list[].b", "playerInfo.newField"}
+	if got := DiffJSONKeys(api, client); !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffJSONKeys() = %v, want %v", got, want)
+	}
+}
+
+// TestNormalizeJSON checks that documents differing only in key order, whitespace and number format are equal once normalized.
+func TestNormalizeJSON(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{`{"b":1,"a":[2.0,3e0]}`, `{ "a": [2, 3], "b": 1.00 }`},
+		{`{"x":0.5,"y":-0}`, `{"y":0,"x":5e-1}`},
+	}
+
+	for _, tt := range tests {
+		a, b := NormalizeJSON([]byte(tt.a)), NormalizeJSON([]byte(tt.b))
+		if string(a) != string(b) {
+			t.Errorf("NormalizeJSON(%s) = %s, NormalizeJSON(%s) = %s, want equal", tt.a, a, tt.b, b)
+		}
+	}
+}
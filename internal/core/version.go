@@ -0,0 +1,9 @@
+package core
+
+// Version is the version of the library. It is updated with each release.
+const Version = "0.5.5"
+
+// DefaultUserAgent is the User-Agent sent when none is provided with WithUserAgent. It
+// identifies the library and its version, and WithUserAgentSuffix can append the name of
+// the application to it.
+const DefaultUserAgent = "enkanetwork-go/" + Version
@@ -0,0 +1,108 @@
+// Package jsonutil provides JSON helpers shared by the model types of the library. It
+// depends only on the standard library, so that the models package stays free of the
+// HTTP client.
+package jsonutil
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// UnknownFields returns the top-level keys of the JSON object in data that do not
+// correspond to any field of the struct type of v. It is used by the model types to
+// preserve fields the API returns but the library does not model yet.
+//
+// Field names are matched against the json tags of v (or the field name if no tag
+// is set) case-insensitively, mirroring the behavior of encoding/json.
+//
+// Parameters:
+//   - data: The JSON object to inspect.
+//   - v: A struct or pointer to a struct describing the known fields.
+//
+// Returns:
+//   - A map of the unknown keys to their raw values, or nil if there are none.
+//   - An error if data is not a JSON object.
+func UnknownFields(data []byte, v any) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	known := make(map[string]bool, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		known[strings.ToLower(name)] = true
+	}
+
+	var extra map[string]json.RawMessage
+	for key, value := range fields {
+		if known[strings.ToLower(key)] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]json.RawMessage)
+		}
+		extra[key] = value
+	}
+
+	return extra, nil
+}
+
+// UnmarshalExtra decodes the JSON object in data into v and returns its fields that do
+// not correspond to any field of v (see UnknownFields). It implements the UnmarshalJSON
+// method of the types with an Extra field; v must point to a type without that method,
+// usually a local type defined from the type being decoded, to avoid infinite recursion:
+//
+//	func (p *Profile) UnmarshalJSON(data []byte) error {
+//	    type profile Profile
+//	    var aux profile
+//	    extra, err := jsonutil.UnmarshalExtra(data, &aux)
+//	    if err != nil {
+//	        return err
+//	    }
+//	    *p = Profile(aux)
+//	    p.Extra = extra
+//	    return nil
+//	}
+func UnmarshalExtra[T any](data []byte, v *T) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return UnknownFields(data, v)
+}
+
+// MarshalExtra encodes v as a JSON object along with the fields in extra, so that the
+// fields stored by UnmarshalExtra survive a round trip, e.g. through a cache persisting
+// values as JSON. The fields of v take precedence over extra fields with the same name.
+// Like with UnmarshalExtra, v must be of a type without a MarshalJSON method.
+func MarshalExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for key, value := range extra {
+		if _, ok := fields[key]; !ok {
+			fields[key] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
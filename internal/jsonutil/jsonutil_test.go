@@ -0,0 +1,71 @@
+package jsonutil
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// known is a struct with the kinds of fields UnknownFields has to recognize.
+type known struct {
+	Tagged   int                        `json:"tagged,omitempty"`
+	Untagged string                     // Matched by its field name
+	Skipped  bool                       `json:"-"`
+	Extra    map[string]json.RawMessage `json:"-"`
+}
+
+// TestUnknownFields checks which keys are reported as unknown.
+func TestUnknownFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]json.RawMessage
+		wantErr bool
+	}{
+		{"none", `{"tagged":1,"Untagged":"a"}`, nil, false},
+		{"case insensitive", `{"TAGGED":1,"untagged":"a"}`, nil, false},
+		{"unknown", `{"tagged":1,"new":[1,2]}`, map[string]json.RawMessage{"new": json.RawMessage(`[1,2]`)}, false},
+		{"ignored field", `{"Skipped":true}`, map[string]json.RawMessage{"Skipped": json.RawMessage(`true`)}, false},
+		{"not an object", `[1]`, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := UnknownFields([]byte(tt.data), &known{})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: UnknownFields() error = %v, want error %v", tt.name, err, tt.wantErr)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: UnknownFields() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestExtraRoundTrip checks that the fields kept by UnmarshalExtra are encoded again by
+// MarshalExtra, without overriding the known fields.
+func TestExtraRoundTrip(t *testing.T) {
+	var v known
+	extra, err := UnmarshalExtra([]byte(`{"tagged":1,"Untagged":"a","new":{"b":2}}`), &v)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v.Tagged != 1 || v.Untagged != "a" {
+		t.Errorf("UnmarshalExtra() decoded %+v", v)
+	}
+
+	extra["tagged"] = json.RawMessage(`5`)
+	data, err := MarshalExtra(v, extra)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"Untagged":"a","new":{"b":2},"tagged":1}`; string(data) != want {
+		t.Errorf("MarshalExtra() = %s, want %s", data, want)
+	}
+
+	data, err = MarshalExtra(v, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := `{"tagged":1,"Untagged":"a"}`; string(data) != want {
+		t.Errorf("MarshalExtra() without extra = %s, want %s", data, want)
+	}
+}
@@ -0,0 +1,21 @@
+// Package testutil provides helpers shared by the tests of the client packages. It
+// depends only on the standard library.
+package testutil
+
+import (
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Response returns a canned response to req with the given status code and body. The
+// client tests return it from an HTTPDoerFunc set with WithHTTPDoer, so that no request
+// leaves the process.
+func Response(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
@@ -0,0 +1,11 @@
+package models
+
+// BuildSettings represents the display settings of a build saved on EnkaNetwork, used to
+// render its card. It is shared by the builds of all games.
+type BuildSettings struct {
+	AdaptiveColor *bool    `json:"adaptiveColor,omitempty"` // Whether adaptive color is enabled
+	ArtSource     *string  `json:"artSource,omitempty"`     // Source of the image
+	Caption       *string  `json:"caption,omitempty"`       // Caption of the build
+	HonkardWidth  *float64 `json:"honkardWidth,omitempty"`  // Width of the image on the card ("honkard" is EnkaNetwork's name for the card)
+	Transform     *string  `json:"transform,omitempty"`     // Transformation applied to the image
+}
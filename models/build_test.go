@@ -0,0 +1,48 @@
+package models
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// TestDecodeBuildSettings checks that every build setting decodes and re-encodes unchanged.
+func TestDecodeBuildSettings(t *testing.T) {
+	data, err := os.ReadFile("testdata/build_settings.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+
+	var settings BuildSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("failed to decode settings: %v", err)
+	}
+
+	adaptiveColor := true
+	artSource := "https://cdn.enka.network/builds/art/4Wjv2e.png"
+	caption := "Hyperbloom"
+	honkardWidth := 1.25
+	transform := "translate(-12%, 4%) scale(1.1)"
+	want := BuildSettings{
+		AdaptiveColor: &adaptiveColor,
+		ArtSource:     &artSource,
+		Caption:       &caption,
+		HonkardWidth:  &honkardWidth,
+		Transform:     &transform,
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("decoded settings = %+v, want %+v", settings, want)
+	}
+
+	encoded, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatalf("failed to encode settings: %v", err)
+	}
+	var got, expected map[string]any
+	json.Unmarshal(encoded, &got)
+	json.Unmarshal(data, &expected)
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("re-encoded settings = %s, want %s", encoded, data)
+	}
+}
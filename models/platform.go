@@ -0,0 +1,66 @@
+package models
+
+import "strings"
+
+// Platform is the platform a game account is played on. Games report it differently:
+// Honkai: Star Rail as a string (DetailInfo.Platform) and Zenless Zone Zero as a number
+// (ProfileDetail.PlatformType). Platform normalizes both into one comparable value.
+type Platform int
+
+// Platforms reported by the games.
+const (
+	PlatformUnknown     Platform = iota // The platform is not reported or not recognized
+	PlatformPC                          // Windows PC
+	PlatformMobile                      // Android or iOS
+	PlatformPlayStation                 // PlayStation 4 or 5
+)
+
+// String returns the name of the platform, e.g. "PC" or "Mobile".
+func (p Platform) String() string {
+	switch p {
+	case PlatformPC:
+		return "PC"
+	case PlatformMobile:
+		return "Mobile"
+	case PlatformPlayStation:
+		return "PlayStation"
+	default:
+		return "Unknown"
+	}
+}
+
+// PlatformFromString translates a platform string, as found in the Platform field of
+// Honkai: Star Rail profiles, into a Platform. The comparison is case-insensitive; both
+// generic names ("Mobile") and operating systems ("Android", "iOS") are recognized.
+// Unrecognized values result in PlatformUnknown.
+func PlatformFromString(s string) Platform {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "PC", "WINDOWS":
+		return PlatformPC
+	case "MOBILE", "ANDROID", "IOS":
+		return PlatformMobile
+	case "PS4", "PS5", "PLAYSTATION":
+		return PlatformPlayStation
+	default:
+		return PlatformUnknown
+	}
+}
+
+// PlatformFromType translates a platform number, as found in the PlatformType field of
+// Zenless Zone Zero profiles (1: PC, 2: Mobile), into a Platform. Unrecognized values
+// result in PlatformUnknown.
+func PlatformFromType(t int) Platform {
+	switch t {
+	case 1:
+		return PlatformPC
+	case 2:
+		return PlatformMobile
+	default:
+		return PlatformUnknown
+	}
+}
+
+// Platform returns the platform of the account, translated from PlatformType.
+func (p *ProfileDetail) Platform() Platform {
+	return PlatformFromType(p.PlatformType)
+}
@@ -1,5 +1,11 @@
 package models
 
+import (
+	"encoding/json"
+
+	"github.com/kirinyoku/enkanetwork-go/internal/jsonutil"
+)
+
 // PlayerInfo contains basic information about the player's game account from their showcase.
 type PlayerInfo struct {
 	// -------------------------------------- Common Fields --------------------------------
@@ -26,7 +32,7 @@ type PlayerInfo struct {
 	// ------------------------------------ HONKAI: STAR RAIL ------------------------------------
 	HeadIcon           int                 `json:"headIcon,omitempty"`           // Profile picture ID
 	Birthday           int                 `json:"birthday,omitempty"`           // Player birthday
-	Platform           string              `json:"platform,omitempty"`           // Platform (e.g. PC, Mobile)
+	Platform           string              `json:"platform,omitempty"`           // Platform (e.g. PC, Mobile); see PlatformFromString
 	FriendCount        int                 `json:"friendCount,omitempty"`        // Number of friends
 	IsDisplayAvatar    bool                `json:"isDisplayAvatar,omitempty"`    // Whether characters are displayed
 	AvatarDetailList   []AvatarDetail      `json:"avatarDetailList,omitempty"`   // List of character details
@@ -46,6 +52,21 @@ type Owner struct {
 	Profile  *PatreonProfile `json:"profile,omitempty"`  // Patreon profile data for Patreon members
 }
 
+// IsPatron reports whether the user supports EnkaNetwork on Patreon. It is safe to call on
+// a nil Owner, such as the Owner of a profile that is not linked to an Enka account.
+func (o *Owner) IsPatron() bool {
+	return o.PatreonTier() > 0
+}
+
+// PatreonTier returns the Patreon membership level of the user, or 0 if the user is not a
+// Patreon member. It is safe to call on a nil Owner.
+func (o *Owner) PatreonTier() int {
+	if o == nil || o.Profile == nil {
+		return 0
+	}
+	return o.Profile.Level
+}
+
 // PatreonProfile contains Patreon-related information for an Enka user.
 type PatreonProfile struct {
 	Bio      string `json:"bio,omitempty"`       // User bio from Patreon
@@ -56,9 +77,20 @@ type PatreonProfile struct {
 
 // Medal represents a badge in Zenless Zone Zero.
 type Medal struct {
-	Value     int `json:"Value,omitempty"`     // Progress number
-	MedalIcon int `json:"MedalIcon,omitempty"` // Icon ID
-	MedalType int `json:"MedalType,omitempty"` // Badge type (see https://github.com/EnkaNetwork/API-docs/blob/master/docs/zzz/api.md#badge-type)
+	Value      int `json:"Value,omitempty"`      // Progress number
+	MedalIcon  int `json:"MedalIcon,omitempty"`  // Icon ID
+	MedalType  int `json:"MedalType,omitempty"`  // Badge type (see https://github.com/EnkaNetwork/API-docs/blob/master/docs/zzz/api.md#badge-type)
+	MedalScore int `json:"MedalScore,omitempty"` // Badge score
+}
+
+// TotalMedalScore returns the sum of the scores of the badges in MedalList, or 0 if the
+// player is not a Zenless Zone Zero player or displays no badges.
+func (p *PlayerInfo) TotalMedalScore() int {
+	total := 0
+	for _, medal := range p.MedalList {
+		total += medal.MedalScore
+	}
+	return total
 }
 
 // ProfileDetail contains detailed player profile information for Zenless Zone Zero.
@@ -76,9 +108,36 @@ type ProfileDetail struct {
 
 // TitleInfo contains title-related information for Zenless Zone Zero.
 type TitleInfo struct {
-	Title       int   `json:"Title,omitempty"`       // Title ID
-	ECJPEHHALAO int   `json:"ECJPEHHALAO,omitempty"` // ????????
-	HFKHLLBMPHM []any `json:"HFKHLLBMPHM,omitempty"` // ????????
+	Title     int `json:"Title,omitempty"`     // Title ID
+	FullTitle int `json:"FullTitle,omitempty"` // ID of the full title, including its prefix
+	// Extra contains all other fields of the title information. Their names are not
+	// stable: the API has exposed them under obfuscated keys (e.g., "ECJPEHHALAO") that
+	// change between game versions, so they are kept undecoded
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for TitleInfo. It decodes
+// Title and FullTitle and keeps the obfuscated fields in Extra.
+func (t *TitleInfo) UnmarshalJSON(data []byte) error {
+	type titleInfo TitleInfo
+
+	var aux titleInfo
+	extra, err := jsonutil.UnmarshalExtra(data, &aux)
+	if err != nil {
+		return err
+	}
+
+	*t = TitleInfo(aux)
+	t.Extra = extra
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface for TitleInfo. The fields in Extra are
+// encoded along with the others, so they survive a round trip.
+func (t TitleInfo) MarshalJSON() ([]byte, error) {
+	type titleInfo TitleInfo
+	return jsonutil.MarshalExtra(titleInfo(t), t.Extra)
 }
 
 // ShowAvatarInfo contains information about a character displayed in the player's showcase.
@@ -107,8 +166,11 @@ type RecordInfo struct {
 	MaxRogueChallengeScore int            `json:"maxRogueChallengeScore,omitempty"` // Maximum rogue challenge score
 }
 
-// ChallengeInfo represents challenge-related data for Honkai: Star Rail (currently empty).
+// ChallengeInfo contains endgame challenge progress for Honkai: Star Rail.
 type ChallengeInfo struct {
+	ScheduleMaxLevel     int `json:"scheduleMaxLevel,omitempty"`     // Highest Memory of Chaos stage cleared in the current schedule
+	ScheduleGroupID      int `json:"scheduleGroupId,omitempty"`      // ID of the current Memory of Chaos schedule
+	NoneScheduleMaxLevel int `json:"noneScheduleMaxLevel,omitempty"` // Highest Forgotten Hall: Memory stage cleared
 }
 
 // PrivacySettingInfo contains privacy settings for a Honkai: Star Rail player.
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestOwnerPatreon(t *testing.T) {
+	tests := []struct {
+		name       string
+		owner      *Owner
+		wantTier   int
+		wantPatron bool
+	}{
+		{"nil owner", nil, 0, false},
+		{"no profile", &Owner{Username: "Algoinde"}, 0, false},
+		{"level 0", &Owner{Profile: &PatreonProfile{}}, 0, false},
+		{"level 3", &Owner{Profile: &PatreonProfile{Level: 3}}, 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.owner.PatreonTier(); got != tt.wantTier {
+				t.Errorf("PatreonTier() = %v, want %v", got, tt.wantTier)
+			}
+			if got := tt.owner.IsPatron(); got != tt.wantPatron {
+				t.Errorf("IsPatron() = %v, want %v", got, tt.wantPatron)
+			}
+		})
+	}
+}
+
+// TestPlayerInfoProgress checks that the progress accessors group the matching fields.
+func TestPlayerInfoProgress(t *testing.T) {
+	p := &PlayerInfo{
+		TowerFloorIndex:  12,
+		TowerLevelIndex:  3,
+		TowerStarIndex:   36,
+		TheaterActIndex:  10,
+		TheaterModeIndex: 3,
+		TheaterStarIndex: 10,
+		StygianIndex:     4,
+		StygianSeconds:   95,
+	}
+
+	if got, want := p.SpiralAbyss(), (AbyssProgress{Floor: 12, Chamber: 3, Stars: 36}); got != want {
+		t.Errorf("SpiralAbyss() = %+v, want %+v", got, want)
+	}
+	if got, want := p.TheaterProgress(), (TheaterProgress{Act: 10, Mode: 3, Stars: 10}); got != want {
+		t.Errorf("TheaterProgress() = %+v, want %+v", got, want)
+	}
+	if got, want := p.StygianProgress(), (StygianProgress{Difficulty: 4, Time: 95 * time.Second}); got != want {
+		t.Errorf("StygianProgress() = %+v, want %+v", got, want)
+	}
+}
+
+// TestPlayerInfoTotalMedalScore checks that MedalScore is decoded and summed over the badges.
+func TestPlayerInfoTotalMedalScore(t *testing.T) {
+	data := `{"MedalList":[{"Value":30,"MedalIcon":3,"MedalType":1,"MedalScore":30},{"Value":7,"MedalIcon":4,"MedalType":2,"MedalScore":12},{"MedalIcon":5}]}`
+
+	var p PlayerInfo
+	if err := json.Unmarshal([]byte(data), &p); err != nil {
+		t.Fatal(err)
+	}
+	if got := p.MedalList[1].MedalScore; got != 12 {
+		t.Errorf("MedalList[1].MedalScore = %v, want %v", got, 12)
+	}
+	if got := p.TotalMedalScore(); got != 42 {
+		t.Errorf("TotalMedalScore() = %v, want %v", got, 42)
+	}
+}
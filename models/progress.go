@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// AbyssProgress is the Spiral Abyss progress shown in a Genshin Impact showcase. All its
+// fields are 0 if the player has not cleared any chamber or does not display it.
+type AbyssProgress struct {
+	Floor   int // Floor reached (TowerFloorIndex)
+	Chamber int // Chamber reached on Floor (TowerLevelIndex)
+	Stars   int // Stars earned (TowerStarIndex)
+}
+
+// TheaterProgress is the Imaginarium Theater progress shown in a Genshin Impact showcase.
+// All its fields are 0 if the player has not entered the theater or does not display it.
+type TheaterProgress struct {
+	Act   int // Act reached (TheaterActIndex)
+	Mode  int // Difficulty mode (TheaterModeIndex)
+	Stars int // Stars earned (TheaterStarIndex)
+}
+
+// StygianProgress is the Stygian Onslaught progress shown in a Genshin Impact showcase.
+// All its fields are 0 if the player has not cleared it or does not display it.
+type StygianProgress struct {
+	Difficulty int           // Difficulty mode cleared (StygianIndex)
+	Time       time.Duration // Clear time (StygianSeconds)
+}
+
+// SpiralAbyss returns the Spiral Abyss progress of a Genshin Impact player, grouping the
+// TowerFloorIndex, TowerLevelIndex and TowerStarIndex fields.
+func (p *PlayerInfo) SpiralAbyss() AbyssProgress {
+	return AbyssProgress{
+		Floor:   p.TowerFloorIndex,
+		Chamber: p.TowerLevelIndex,
+		Stars:   p.TowerStarIndex,
+	}
+}
+
+// TheaterProgress returns the Imaginarium Theater progress of a Genshin Impact player,
+// grouping the TheaterActIndex, TheaterModeIndex and TheaterStarIndex fields.
+func (p *PlayerInfo) TheaterProgress() TheaterProgress {
+	return TheaterProgress{
+		Act:   p.TheaterActIndex,
+		Mode:  p.TheaterModeIndex,
+		Stars: p.TheaterStarIndex,
+	}
+}
+
+// StygianProgress returns the Stygian Onslaught progress of a Genshin Impact player,
+// grouping the StygianIndex and StygianSeconds fields.
+func (p *PlayerInfo) StygianProgress() StygianProgress {
+	return StygianProgress{
+		Difficulty: p.StygianIndex,
+		Time:       time.Duration(p.StygianSeconds) * time.Second,
+	}
+}
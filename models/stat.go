@@ -0,0 +1,11 @@
+package models
+
+// StatEntry is a single stat of a character, such as its CRIT Rate. It gives the games'
+// different stat representations a common shape, e.g. to render a stat table for any of
+// them. Each game identifies stats differently, so either ID or Name may be empty; see
+// the AvatarStats method of each game package.
+type StatEntry struct {
+	ID    int     `json:"id,omitempty"`   // Numeric ID of the stat, if the game has one
+	Name  string  `json:"name,omitempty"` // Name of the stat, if the game provides one
+	Value float64 `json:"value"`          // Value of the stat
+}